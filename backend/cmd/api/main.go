@@ -5,12 +5,16 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // calendar timezones in minimal images
 
 	"gh-ts/internal/config"
 	"gh-ts/internal/database"
 	"gh-ts/internal/router"
+	"gh-ts/internal/service"
+	"gh-ts/internal/worker"
 	"gh-ts/pkg/logger"
 )
 
@@ -26,8 +30,42 @@ func main() {
 	}
 	defer pool.Close()
 
+	// services (shared by the http router and the background jobs)
+	svc, err := router.NewServices(l, pool, cfg)
+	if err != nil {
+		l.Fatal().Err(err).Msg("upload storage init failed")
+	}
+
 	// http
-	r := router.New(l, pool, cfg)
+	r := router.New(l, svc, cfg)
+
+	// background jobs (stopped and awaited during graceful shutdown)
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	defer bgCancel()
+	var bg sync.WaitGroup
+
+	// reap stored attachment files that no DB row references
+	bg.Add(1)
+	go func() {
+		defer bg.Done()
+		worker.Every(bgCtx, time.Hour, func(ctx context.Context) {
+			if n, err := svc.Attachments.CleanupOrphans(ctx, time.Hour); err != nil && ctx.Err() == nil {
+				l.Error().Err(err).Msg("attachment cleanup failed")
+			} else if n > 0 {
+				l.Info().Int("removed", n).Msg("attachment cleanup")
+			}
+		})
+	}()
+
+	// SLA escalation (leader-elected via Postgres advisory lock)
+	if cfg.SLAWorkerInterval > 0 {
+		escalator := worker.NewSLAEscalator(pool, svc.Tickets, svc.Ticket, service.ParseEscalationActions(cfg.SLAEscalationActions), l)
+		bg.Add(1)
+		go func() {
+			defer bg.Done()
+			escalator.Run(bgCtx, cfg.SLAWorkerInterval)
+		}()
+	}
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
@@ -50,5 +88,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	_ = srv.Shutdown(ctx)
+	bgCancel()
+	bg.Wait()
 	l.Info().Msg("shutdown complete")
 }
@@ -0,0 +1,195 @@
+// Package calendar does working-time arithmetic over weekly business hours,
+// a timezone and a holiday list. A nil *Calendar means "always open" (24x7),
+// so callers can treat wall-clock SLAs and business-hour SLAs the same way.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScanDays bounds day-by-day scans so a misconfigured calendar can never
+// loop forever.
+const maxScanDays = 3 * 366
+
+const dateLayout = "2006-01-02"
+
+// Window is a span of working time within a day, in minutes since local
+// midnight: [Start, End).
+type Window struct {
+	Start int
+	End   int
+}
+
+// Calendar holds business hours per weekday and non-working dates.
+type Calendar struct {
+	loc      *time.Location
+	week     [7][]Window
+	holidays map[string]struct{} // local dates, YYYY-MM-DD
+}
+
+// New validates and builds a calendar. Windows must lie within a day and must
+// not overlap; at least one weekday must have working time. Holidays are
+// local dates in YYYY-MM-DD form.
+func New(loc *time.Location, week map[time.Weekday][]Window, holidays []string) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	c := &Calendar{loc: loc, holidays: make(map[string]struct{}, len(holidays))}
+	open := false
+	for day, ws := range week {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("invalid weekday %d", day)
+		}
+		sorted := append([]Window(nil), ws...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+		for i, w := range sorted {
+			if w.Start < 0 || w.End > 24*60 || w.Start >= w.End {
+				return nil, fmt.Errorf("invalid hours on %s", day)
+			}
+			if i > 0 && w.Start < sorted[i-1].End {
+				return nil, fmt.Errorf("overlapping hours on %s", day)
+			}
+		}
+		c.week[day] = sorted
+		open = open || len(sorted) > 0
+	}
+	if !open {
+		return nil, errors.New("calendar has no business hours")
+	}
+	for _, h := range holidays {
+		d, err := time.Parse(dateLayout, strings.TrimSpace(h))
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q", h)
+		}
+		c.holidays[d.Format(dateLayout)] = struct{}{}
+	}
+	return c, nil
+}
+
+// ParseClock parses "HH:MM" into minutes since midnight ("24:00" is allowed
+// as an end of day).
+func ParseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, err1 := strconv.Atoi(hh)
+	m, err2 := strconv.Atoi(mm)
+	if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// Location returns the calendar's timezone (UTC for a nil calendar).
+func (c *Calendar) Location() *time.Location {
+	if c == nil {
+		return time.UTC
+	}
+	return c.loc
+}
+
+// Add returns the instant d of working time after t.
+func (c *Calendar) Add(t time.Time, d time.Duration) time.Time {
+	if c == nil {
+		return t.Add(d)
+	}
+	if d <= 0 {
+		return t
+	}
+	cur := t.In(c.loc)
+	for i := 0; i < maxScanDays; i++ {
+		// A window ending at 24:00 leaves cur on the next midnight already,
+		// so advance from the day being scanned rather than from cur.
+		day := cur
+		for _, w := range c.windows(day) {
+			start, end := w[0], w[1]
+			if cur.Before(start) {
+				cur = start
+			}
+			if !cur.Before(end) {
+				continue
+			}
+			if avail := end.Sub(cur); d <= avail {
+				return cur.Add(d)
+			} else {
+				d -= avail
+				cur = end
+			}
+		}
+		cur = nextMidnight(day)
+	}
+	// Unreachable for validated calendars; fall back to wall clock.
+	return t.Add(d)
+}
+
+// Between returns the working time elapsed from a to b (0 if b is not after a).
+func (c *Calendar) Between(a, b time.Time) time.Duration {
+	if c == nil {
+		if b.After(a) {
+			return b.Sub(a)
+		}
+		return 0
+	}
+	var total time.Duration
+	cur := a.In(c.loc)
+	for i := 0; cur.Before(b) && i < maxScanDays*10; i++ {
+		for _, w := range c.windows(cur) {
+			start, end := w[0], w[1]
+			if start.Before(cur) {
+				start = cur
+			}
+			if end.After(b) {
+				end = b
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		cur = nextMidnight(cur)
+	}
+	return total
+}
+
+// IsWorking reports whether t falls within business hours.
+func (c *Calendar) IsWorking(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	t = t.In(c.loc)
+	for _, w := range c.windows(t) {
+		if !t.Before(w[0]) && t.Before(w[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// windows returns the working spans of the local day containing t as
+// absolute [start, end) pairs; empty on holidays.
+func (c *Calendar) windows(t time.Time) [][2]time.Time {
+	y, m, d := t.Date()
+	if _, ok := c.holidays[time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Format(dateLayout)]; ok {
+		return nil
+	}
+	ws := c.week[t.Weekday()]
+	out := make([][2]time.Time, 0, len(ws))
+	for _, w := range ws {
+		// time.Date normalises minute overflow, which keeps DST days correct
+		out = append(out, [2]time.Time{
+			time.Date(y, m, d, 0, w.Start, 0, 0, c.loc),
+			time.Date(y, m, d, 0, w.End, 0, 0, c.loc),
+		})
+	}
+	return out
+}
+
+func nextMidnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
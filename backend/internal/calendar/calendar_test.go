@@ -0,0 +1,131 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoc(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func mustCalendar(t *testing.T, loc *time.Location, days []time.Weekday, ws []Window, holidays ...string) *Calendar {
+	t.Helper()
+	week := map[time.Weekday][]Window{}
+	for _, d := range days {
+		week[d] = ws
+	}
+	c, err := New(loc, week, holidays)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+var (
+	allDays  = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	fullDay   = []Window{{Start: 0, End: 24 * 60}}
+	lateNight = []Window{{Start: 22 * 60, End: 24 * 60}}
+	office    = []Window{{Start: 9 * 60, End: 17 * 60}}
+)
+
+func TestAddAndBetween(t *testing.T) {
+	berlin := mustLoc(t, "Europe/Berlin")
+	at := func(loc *time.Location, s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, loc)
+		if err != nil {
+			t.Fatalf("parse %q: %v", s, err)
+		}
+		return v
+	}
+
+	cal24x7 := mustCalendar(t, time.UTC, allDays, fullDay)
+	calLate := mustCalendar(t, time.UTC, allDays, lateNight)
+	calOffice := mustCalendar(t, time.UTC, weekdays, office)
+	calHoliday := mustCalendar(t, time.UTC, weekdays, office, "2026-01-06")
+	calBerlin := mustCalendar(t, berlin, allDays, fullDay)
+	calBerlinOffice := mustCalendar(t, berlin, allDays, office)
+
+	// 2026-01-05 is a Monday. Berlin switches to CEST on 2026-03-29 and back
+	// on 2026-10-25.
+	tests := []struct {
+		name  string
+		cal   *Calendar
+		start time.Time
+		d     time.Duration
+		want  time.Time
+	}{
+		{"nil calendar is wall clock", nil, at(time.UTC, "2026-01-05 12:00"), 30 * time.Hour, at(time.UTC, "2026-01-06 18:00")},
+		{"24x7 one day", cal24x7, at(time.UTC, "2026-01-05 12:00"), 24 * time.Hour, at(time.UTC, "2026-01-06 12:00")},
+		{"24x7 several days", cal24x7, at(time.UTC, "2026-01-05 00:00"), 72 * time.Hour, at(time.UTC, "2026-01-08 00:00")},
+		{"window to 24:00 spills to next day", calLate, at(time.UTC, "2026-01-05 23:00"), 2 * time.Hour, at(time.UTC, "2026-01-06 23:00")},
+		{"window to 24:00 used up exactly", calLate, at(time.UTC, "2026-01-05 23:00"), time.Hour, at(time.UTC, "2026-01-06 00:00")},
+		{"before opening", calOffice, at(time.UTC, "2026-01-05 07:00"), time.Hour, at(time.UTC, "2026-01-05 10:00")},
+		{"over the weekend", calOffice, at(time.UTC, "2026-01-09 16:00"), 2 * time.Hour, at(time.UTC, "2026-01-12 10:00")},
+		{"skips holiday", calHoliday, at(time.UTC, "2026-01-05 16:00"), 2 * time.Hour, at(time.UTC, "2026-01-07 10:00")},
+		{"starts on holiday", calHoliday, at(time.UTC, "2026-01-06 10:00"), time.Hour, at(time.UTC, "2026-01-07 10:00")},
+		{"24x7 across spring forward", calBerlin, at(berlin, "2026-03-28 12:00"), 24 * time.Hour, at(berlin, "2026-03-29 13:00")},
+		{"24x7 across fall back", calBerlin, at(berlin, "2026-10-24 12:00"), 24 * time.Hour, at(berlin, "2026-10-25 11:00")},
+		{"office hours across spring forward", calBerlinOffice, at(berlin, "2026-03-28 16:00"), 2 * time.Hour, at(berlin, "2026-03-29 10:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cal.Add(tt.start, tt.d)
+			if !got.Equal(tt.want) {
+				t.Fatalf("Add(%v, %v) = %v, want %v", tt.start, tt.d, got, tt.want)
+			}
+			if back := tt.cal.Between(tt.start, got); back != tt.d {
+				t.Fatalf("Between(%v, %v) = %v, want %v", tt.start, got, back, tt.d)
+			}
+		})
+	}
+}
+
+func TestAddNonPositive(t *testing.T) {
+	c := mustCalendar(t, time.UTC, weekdays, office)
+	start := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC) // Saturday, closed
+	for _, d := range []time.Duration{0, -time.Hour} {
+		if got := c.Add(start, d); !got.Equal(start) {
+			t.Errorf("Add(%v) = %v, want %v", d, got, start)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	berlin := mustLoc(t, "Europe/Berlin")
+	utc := func(day, hour int) time.Time { return time.Date(2026, 1, day, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		cal  *Calendar
+		a, b time.Time
+		want time.Duration
+	}{
+		{"nil calendar", nil, utc(5, 12), utc(6, 12), 24 * time.Hour},
+		{"nil calendar reversed", nil, utc(6, 12), utc(5, 12), 0},
+		{"reversed", mustCalendar(t, time.UTC, allDays, fullDay), utc(6, 12), utc(5, 12), 0},
+		{"same instant", mustCalendar(t, time.UTC, allDays, fullDay), utc(5, 12), utc(5, 12), 0},
+		{"window to 24:00", mustCalendar(t, time.UTC, allDays, lateNight), utc(5, 23), utc(6, 23), 2 * time.Hour},
+		{"outside hours", mustCalendar(t, time.UTC, weekdays, office), utc(5, 18), utc(6, 8), 0},
+		{"holiday counts nothing", mustCalendar(t, time.UTC, weekdays, office, "2026-01-06"), utc(5, 17), utc(7, 9), 0},
+		{"spring forward day is 23h", mustCalendar(t, berlin, allDays, fullDay),
+			time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), 23 * time.Hour},
+		{"fall back day is 25h", mustCalendar(t, berlin, allDays, fullDay),
+			time.Date(2026, 10, 25, 0, 0, 0, 0, berlin), time.Date(2026, 10, 26, 0, 0, 0, 0, berlin), 25 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cal.Between(tt.a, tt.b); got != tt.want {
+				t.Fatalf("Between = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Lifecycle stamps maintained by the ticket status state machine.
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS closed_at   TIMESTAMPTZ NULL;
+
+-- Backfill from updated_at for tickets that are already past resolution.
+UPDATE tickets
+SET resolved_at = updated_at
+WHERE status IN ('Resolved', 'Closed') AND resolved_at IS NULL;
+
+UPDATE tickets
+SET closed_at = updated_at
+WHERE status = 'Closed' AND closed_at IS NULL;
+
+-- +goose Down
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS resolved_at;
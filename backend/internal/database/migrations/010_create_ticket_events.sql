@@ -0,0 +1,18 @@
+-- +goose Up
+-- Audit trail of ticket changes. One row per changed field (event_type 'change')
+-- or per higher-level action recorded by the service layer.
+CREATE TABLE IF NOT EXISTS ticket_events (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id  UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    actor_id   UUID        NULL REFERENCES users(id) ON DELETE SET NULL, -- NULL = system
+    event_type TEXT        NOT NULL DEFAULT 'change',
+    field      TEXT        NOT NULL DEFAULT '',
+    old_value  TEXT        NULL,
+    new_value  TEXT        NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_events_ticket_created ON ticket_events(ticket_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS ticket_events;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Comment authorship and staff-only internal notes.
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS author_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS internal  BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_comments_author_id ON comments(author_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_comments_author_id;
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS internal,
+    DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Editable comments keep prior text as revisions; deletes are soft.
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS edited_at  TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS deleted_by UUID NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID        NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    text       TEXT        NOT NULL,                                  -- text before the edit
+    edited_by  UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_revisions_comment ON comment_revisions(comment_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_revisions;
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
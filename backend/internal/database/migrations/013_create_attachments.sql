@@ -0,0 +1,20 @@
+-- +goose Up
+-- File attachments on tickets (comment_id NULL) or on a specific comment.
+-- Contents live in the configured storage backend under storage_key.
+CREATE TABLE IF NOT EXISTS attachments (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id    UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    comment_id   UUID        NULL REFERENCES comments(id) ON DELETE CASCADE,
+    uploaded_by  UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    filename     TEXT        NOT NULL,
+    content_type TEXT        NOT NULL,
+    size_bytes   BIGINT      NOT NULL,
+    storage_key  TEXT        NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_ticket_id  ON attachments(ticket_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_comment_id ON attachments(comment_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS attachments;
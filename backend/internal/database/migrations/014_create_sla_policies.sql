@@ -0,0 +1,61 @@
+-- +goose Up
+-- SLA policies: response/resolution targets keyed by priority and optionally
+-- narrowed by category and/or department (most specific match wins).
+CREATE TABLE IF NOT EXISTS sla_policies (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                   TEXT        NOT NULL,
+    priority               TEXT        NOT NULL,
+    category               TEXT        NULL,
+    department             TEXT        NULL,
+    first_response_minutes INTEGER     NOT NULL CHECK (first_response_minutes > 0),
+    resolution_minutes     INTEGER     NOT NULL CHECK (resolution_minutes > 0),
+    active                 BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sla_policies_scope_key
+ON sla_policies (priority, COALESCE(category, ''), COALESCE(department, ''));
+
+INSERT INTO sla_policies (name, priority, first_response_minutes, resolution_minutes) VALUES
+    ('Critical', 'Critical',   60,   240),
+    ('High',     'High',      240,  1440),
+    ('Medium',   'Medium',    480,  4320),
+    ('Low',      'Low',      1440, 10080)
+ON CONFLICT DO NOTHING;
+
+-- Per-ticket SLA state. Deadlines are pushed back by the time spent Pending
+-- (sla_paused_seconds); sla_paused_at is set while the clock is stopped.
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS sla_policy_id      UUID        NULL REFERENCES sla_policies(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS first_response_due TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS resolution_due     TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS first_responded_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS sla_paused_at      TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS sla_paused_seconds BIGINT      NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_first_response_due ON tickets(first_response_due) WHERE first_responded_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tickets_resolution_due     ON tickets(resolution_due) WHERE resolved_at IS NULL;
+
+-- Backfill still-open tickets with the priority-level policy.
+UPDATE tickets t
+SET sla_policy_id      = p.id,
+    first_response_due = t.created_at + make_interval(mins => p.first_response_minutes),
+    resolution_due     = t.created_at + make_interval(mins => p.resolution_minutes)
+FROM sla_policies p
+WHERE p.priority = t.priority
+  AND p.category IS NULL AND p.department IS NULL
+  AND t.sla_policy_id IS NULL
+  AND t.status NOT IN ('Resolved', 'Closed');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tickets_resolution_due;
+DROP INDEX IF EXISTS idx_tickets_first_response_due;
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS sla_paused_seconds,
+    DROP COLUMN IF EXISTS sla_paused_at,
+    DROP COLUMN IF EXISTS first_responded_at,
+    DROP COLUMN IF EXISTS resolution_due,
+    DROP COLUMN IF EXISTS first_response_due,
+    DROP COLUMN IF EXISTS sla_policy_id;
+DROP TABLE IF EXISTS sla_policies;
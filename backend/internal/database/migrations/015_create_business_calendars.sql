@@ -0,0 +1,40 @@
+-- +goose Up
+-- Business-hour calendars for SLA math. hours is a JSON object keyed by
+-- weekday (mon..sun) with lists of {"start":"HH:MM","end":"HH:MM"} windows.
+-- A calendar bound to a department applies to that department's tickets;
+-- otherwise the default calendar is used.
+CREATE TABLE IF NOT EXISTS business_calendars (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT        NOT NULL,
+    timezone   TEXT        NOT NULL DEFAULT 'UTC',
+    hours      JSONB       NOT NULL,
+    department TEXT        NULL UNIQUE,
+    is_default BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS business_calendars_default_key
+ON business_calendars (is_default) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS calendar_holidays (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    calendar_id UUID NOT NULL REFERENCES business_calendars(id) ON DELETE CASCADE,
+    day         DATE NOT NULL,
+    name        TEXT NOT NULL DEFAULT '',
+    UNIQUE (calendar_id, day)
+);
+
+INSERT INTO business_calendars (name, timezone, hours, is_default)
+SELECT 'Standard (Mon-Fri 09:00-17:00)', 'UTC', '{
+    "mon": [{"start": "09:00", "end": "17:00"}],
+    "tue": [{"start": "09:00", "end": "17:00"}],
+    "wed": [{"start": "09:00", "end": "17:00"}],
+    "thu": [{"start": "09:00", "end": "17:00"}],
+    "fri": [{"start": "09:00", "end": "17:00"}]
+}'::jsonb, TRUE
+WHERE NOT EXISTS (SELECT 1 FROM business_calendars WHERE is_default);
+
+-- +goose Down
+DROP TABLE IF EXISTS calendar_holidays;
+DROP TABLE IF EXISTS business_calendars;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Highest SLA escalation already applied by the background worker
+-- (0 = none, 1 = at risk, 2 = breached). Reset when deadlines are recomputed.
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS sla_escalation_level SMALLINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS sla_escalation_level;
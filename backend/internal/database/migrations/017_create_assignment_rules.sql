@@ -0,0 +1,24 @@
+-- +goose Up
+-- Auto-assignment rules, evaluated in position order. category/department
+-- NULL = any. strategy: round_robin | least_loaded | user (target_user_id).
+-- last_assigned_user_id is the round-robin cursor for the rule.
+CREATE TABLE IF NOT EXISTS assignment_rules (
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                  TEXT        NOT NULL,
+    position              INTEGER     NOT NULL DEFAULT 0,
+    category              TEXT        NULL,
+    department            TEXT        NULL,
+    strategy              TEXT        NOT NULL,
+    target_user_id        UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    last_assigned_user_id UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    active                BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT assignment_rules_strategy_check
+        CHECK (strategy IN ('round_robin', 'least_loaded', 'user'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_rules_position ON assignment_rules(position) WHERE active;
+
+-- +goose Down
+DROP TABLE IF EXISTS assignment_rules;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Agent skill profiles for skill-based routing. Skills are free-form tags
+-- matched case-insensitively against tickets.category.
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    skill       CITEXT      NOT NULL,
+    proficiency SMALLINT    NOT NULL DEFAULT 3 CHECK (proficiency BETWEEN 1 AND 5),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_skill ON user_skills(skill);
+
+ALTER TABLE assignment_rules DROP CONSTRAINT IF EXISTS assignment_rules_strategy_check;
+ALTER TABLE assignment_rules ADD CONSTRAINT assignment_rules_strategy_check
+    CHECK (strategy IN ('round_robin', 'least_loaded', 'user', 'skills'));
+
+-- Catch-all skill routing; falls through to the admin fallback while no
+-- agent has a matching skill.
+INSERT INTO assignment_rules (name, position, strategy)
+VALUES ('Skill match', 1000, 'skills');
+
+-- +goose Down
+DELETE FROM assignment_rules WHERE strategy = 'skills';
+ALTER TABLE assignment_rules DROP CONSTRAINT IF EXISTS assignment_rules_strategy_check;
+ALTER TABLE assignment_rules ADD CONSTRAINT assignment_rules_strategy_check
+    CHECK (strategy IN ('round_robin', 'least_loaded', 'user'));
+
+DROP TABLE IF EXISTS user_skills;
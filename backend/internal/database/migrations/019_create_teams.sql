@@ -0,0 +1,47 @@
+-- +goose Up
+-- Teams (queues) with members and leads. Tickets can sit in a team queue
+-- (team_id) with or without an individual assignee.
+CREATE TABLE IF NOT EXISTS teams (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        CITEXT      NOT NULL UNIQUE,
+    description TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id    UUID        NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_lead    BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user ON team_members(user_id);
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS team_id UUID NULL REFERENCES teams(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_team_open
+    ON tickets(team_id) WHERE status NOT IN ('Resolved','Closed');
+
+-- Turn existing free-text departments into teams and queue their tickets
+INSERT INTO teams (name)
+SELECT DISTINCT btrim(d)::citext FROM (
+    SELECT department AS d FROM tickets
+    UNION SELECT department FROM sla_policies
+    UNION SELECT department FROM business_calendars
+) src
+WHERE btrim(COALESCE(d, '')) <> ''
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE tickets t SET team_id = tm.id
+FROM teams tm
+WHERE t.team_id IS NULL
+  AND btrim(COALESCE(t.department, '')) <> ''
+  AND tm.name = btrim(t.department)::citext;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tickets_team_open;
+ALTER TABLE tickets DROP COLUMN IF EXISTS team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Row version for optimistic concurrency: every write bumps it and updates
+-- only apply if the caller saw the current version (exposed as the ETag).
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE tickets DROP COLUMN IF EXISTS version;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A merged (duplicate) ticket is closed and points at the ticket it was
+-- folded into; its row and alias stay so old links keep resolving.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS merged_into_id UUID NULL REFERENCES tickets(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_merged_into ON tickets(merged_into_id) WHERE merged_into_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tickets_merged_into;
+ALTER TABLE tickets DROP COLUMN IF EXISTS merged_into_id;
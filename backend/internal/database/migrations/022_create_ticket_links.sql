@@ -0,0 +1,28 @@
+-- +goose Up
+-- Directed links between tickets. kind: parent (source is parent of target),
+-- blocks (source blocks target), relates (symmetric; stored with
+-- source_id < target_id), duplicate (source duplicates target).
+CREATE TABLE IF NOT EXISTS ticket_links (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_id  UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    target_id  UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    kind       TEXT        NOT NULL,
+    created_by UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ticket_links_kind_check CHECK (kind IN ('parent', 'blocks', 'relates', 'duplicate')),
+    CONSTRAINT ticket_links_no_self CHECK (source_id <> target_id),
+    UNIQUE (source_id, target_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_links_target ON ticket_links(target_id);
+
+-- A ticket has at most one parent
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ticket_links_one_parent ON ticket_links(target_id) WHERE kind = 'parent';
+
+-- Earlier merges become duplicate links
+INSERT INTO ticket_links (source_id, target_id, kind)
+SELECT id, merged_into_id, 'duplicate' FROM tickets WHERE merged_into_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS ticket_links;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Prefix search on aliases ("TKT-2025-000%"); the unique lower(alias) index
+-- from 008 serves exact lookups but not LIKE under non-C collations.
+CREATE INDEX IF NOT EXISTS idx_tickets_alias_prefix
+    ON tickets (lower(alias) text_pattern_ops)
+    WHERE alias IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tickets_alias_prefix;
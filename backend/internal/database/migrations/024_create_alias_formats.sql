@@ -0,0 +1,84 @@
+-- +goose Up
+-- Per-category or per-team alias formats: <prefix>[YYYY-]<counter>, each
+-- with its own counter (optionally reset every year). Tickets matching no
+-- format keep the global TKT-YYYY-##### scheme; existing aliases are never
+-- rewritten. A team format wins over a category format.
+CREATE TABLE IF NOT EXISTS alias_formats (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    prefix       TEXT        NOT NULL,
+    category     TEXT        NULL,
+    team_id      UUID        NULL REFERENCES teams(id) ON DELETE CASCADE,
+    include_year BOOLEAN     NOT NULL DEFAULT TRUE,
+    yearly_reset BOOLEAN     NOT NULL DEFAULT TRUE,
+    padding      SMALLINT    NOT NULL DEFAULT 5 CHECK (padding BETWEEN 1 AND 10),
+    counter      BIGINT      NOT NULL DEFAULT 0,
+    counter_year INTEGER     NOT NULL DEFAULT 0,
+    active       BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT alias_formats_scope_check CHECK ((category IS NULL) <> (team_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_alias_formats_prefix ON alias_formats (upper(prefix));
+CREATE UNIQUE INDEX IF NOT EXISTS ux_alias_formats_category ON alias_formats (category) WHERE category IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_alias_formats_team ON alias_formats (team_id) WHERE team_id IS NOT NULL;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ticket_alias_default()
+RETURNS trigger AS $$
+DECLARE
+  f         alias_formats%ROWTYPE;
+  yr        INTEGER := extract(year FROM COALESCE(NEW.created_at, now()))::int;
+  candidate TEXT;
+BEGIN
+  IF NEW.alias IS NOT NULL AND NEW.alias <> '' THEN
+    RETURN NEW;
+  END IF;
+
+  SELECT * INTO f FROM alias_formats
+  WHERE active
+    AND ((team_id IS NOT NULL AND team_id = NEW.team_id)
+      OR (category IS NOT NULL AND category = NEW.category))
+  ORDER BY team_id IS NULL
+  LIMIT 1
+  FOR UPDATE;
+
+  IF NOT FOUND THEN
+    NEW.alias := 'TKT-' || yr || '-' || lpad(nextval('ticket_alias_seq')::text, 5, '0');
+    RETURN NEW;
+  END IF;
+
+  LOOP
+    IF f.yearly_reset AND f.counter_year <> yr THEN
+      f.counter := 0;
+    END IF;
+    f.counter := f.counter + 1;
+    candidate := f.prefix
+      || CASE WHEN f.include_year THEN yr || '-' ELSE '' END
+      || lpad(f.counter::text, f.padding, '0');
+    -- Skip numbers already taken (e.g. a format recreated with an old prefix)
+    EXIT WHEN NOT EXISTS (SELECT 1 FROM tickets WHERE lower(alias) = lower(candidate));
+  END LOOP;
+
+  UPDATE alias_formats SET counter = f.counter, counter_year = yr WHERE id = f.id;
+  NEW.alias := candidate;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ticket_alias_default()
+RETURNS trigger AS $$
+BEGIN
+  IF NEW.alias IS NULL OR NEW.alias = '' THEN
+    NEW.alias := 'TKT-' || to_char(COALESCE(NEW.created_at, now()), 'YYYY')
+      || '-' || lpad(nextval('ticket_alias_seq')::text, 5, '0');
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS alias_formats;
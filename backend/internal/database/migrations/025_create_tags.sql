@@ -0,0 +1,25 @@
+-- +goose Up
+-- Free-form ticket tags. Names are case-insensitive; whether agents may
+-- create tags ad hoc or only admins manage them is an app setting.
+CREATE TABLE IF NOT EXISTS tags (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       CITEXT      NOT NULL UNIQUE,
+    color      TEXT        NOT NULL DEFAULT '',
+    created_by UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS ticket_tags (
+    ticket_id  UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    tag_id     UUID        NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    added_by   UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (ticket_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tags_tag ON ticket_tags(tag_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS ticket_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,112 @@
+-- +goose Up
+-- Admin-managed ticket statuses, priorities and categories. Tickets and
+-- rules keep storing the names; renames are applied to them by the API.
+-- System statuses (New, Pending, Resolved, Closed) drive the lifecycle and
+-- cannot be renamed or deleted.
+CREATE TABLE IF NOT EXISTS ticket_statuses (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          TEXT        NOT NULL,
+    position      INTEGER     NOT NULL DEFAULT 0,
+    color         TEXT        NOT NULL DEFAULT '',
+    is_terminal   BOOLEAN     NOT NULL DEFAULT FALSE,
+    is_system     BOOLEAN     NOT NULL DEFAULT FALSE,
+    next_statuses TEXT[]      NOT NULL DEFAULT '{}', -- allowed transitions
+    active        BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ticket_statuses_name ON ticket_statuses (lower(name));
+
+-- position orders priorities from lowest to highest (escalation goes up);
+-- is_high marks priorities counted as high in reports.
+CREATE TABLE IF NOT EXISTS ticket_priorities (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT        NOT NULL,
+    position   INTEGER     NOT NULL DEFAULT 0,
+    color      TEXT        NOT NULL DEFAULT '',
+    is_default BOOLEAN     NOT NULL DEFAULT FALSE,
+    is_high    BOOLEAN     NOT NULL DEFAULT FALSE,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ticket_priorities_name ON ticket_priorities (lower(name));
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ticket_priorities_default ON ticket_priorities (is_default) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS ticket_categories (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT        NOT NULL,
+    position   INTEGER     NOT NULL DEFAULT 0,
+    color      TEXT        NOT NULL DEFAULT '',
+    active     BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ticket_categories_name ON ticket_categories (lower(name));
+
+INSERT INTO ticket_statuses (name, position, is_terminal, is_system, next_statuses) VALUES
+    ('New',         10, FALSE, TRUE,  '{Open,"In Progress"}'),
+    ('Open',        20, FALSE, FALSE, '{"In Progress",Pending,Resolved}'),
+    ('In Progress', 30, FALSE, FALSE, '{Open,Pending,Resolved}'),
+    ('Pending',     40, FALSE, TRUE,  '{"In Progress",Resolved}'),
+    ('Resolved',    50, TRUE,  TRUE,  '{Closed,Open}'),
+    ('Closed',      60, TRUE,  TRUE,  '{Open}')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ticket_priorities (name, position, is_default, is_high) VALUES
+    ('Low',      10, TRUE,  FALSE),
+    ('Medium',   20, FALSE, FALSE),
+    ('High',     30, FALSE, TRUE),
+    ('Critical', 40, FALSE, TRUE)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ticket_categories (name, position) VALUES
+    ('Software', 10),
+    ('Hardware', 20),
+    ('Network',  30),
+    ('Access',   40),
+    ('General',  50)
+ON CONFLICT DO NOTHING;
+
+-- The open-queue index predicate cannot follow the configurable terminal set
+DROP INDEX IF EXISTS idx_tickets_team_open;
+CREATE INDEX IF NOT EXISTS idx_tickets_team_status ON tickets(team_id, status);
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW reports_summary AS
+SELECT
+  COUNT(*) FILTER (WHERE NOT COALESCE(s.is_terminal, FALSE)) AS open,
+  COUNT(*) FILTER (
+    WHERE COALESCE(s.is_terminal, FALSE)
+      AND COALESCE(t.resolved_at, t.updated_at) > NOW() - INTERVAL '7 days'
+  ) AS resolved7d,
+  COUNT(*) FILTER (
+    WHERE COALESCE(p.is_high, FALSE)
+      AND NOT COALESCE(s.is_terminal, FALSE)
+  ) AS high_critical_open
+FROM tickets t
+LEFT JOIN ticket_statuses s ON s.name = t.status
+LEFT JOIN ticket_priorities p ON p.name = t.priority;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW reports_summary AS
+SELECT
+    COUNT(*) FILTER (WHERE status NOT IN ('Resolved', 'Closed')) AS open,
+  COUNT(*) FILTER (
+    WHERE status = 'Resolved'
+      AND updated_at > NOW() - INTERVAL '7 days'
+  ) AS resolved7d,
+  COUNT(*) FILTER (
+    WHERE priority IN ('High', 'Critical')
+      AND status NOT IN ('Resolved', 'Closed')
+  ) AS high_critical_open
+FROM tickets;
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_tickets_team_status;
+CREATE INDEX IF NOT EXISTS idx_tickets_team_open
+    ON tickets(team_id) WHERE status NOT IN ('Resolved','Closed');
+DROP TABLE IF EXISTS ticket_categories;
+DROP TABLE IF EXISTS ticket_priorities;
+DROP TABLE IF EXISTS ticket_statuses;
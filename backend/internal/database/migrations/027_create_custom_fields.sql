@@ -0,0 +1,29 @@
+-- +goose Up
+-- Admin-defined custom ticket fields. Values live in tickets.custom_fields
+-- keyed by custom_fields.key; categories limits a field to tickets of those
+-- categories (empty = all).
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key        TEXT        NOT NULL,
+    label      TEXT        NOT NULL,
+    type       TEXT        NOT NULL,
+    options    TEXT[]      NOT NULL DEFAULT '{}', -- choices for select/multi_select
+    required   BOOLEAN     NOT NULL DEFAULT FALSE,
+    categories TEXT[]      NOT NULL DEFAULT '{}',
+    position   INTEGER     NOT NULL DEFAULT 0,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT custom_fields_type_check CHECK (type IN ('text', 'number', 'date', 'select', 'multi_select', 'user'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_custom_fields_key ON custom_fields (lower(key));
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+-- Containment lookups (custom_fields @> '{"key": value}') for list filters
+CREATE INDEX IF NOT EXISTS idx_tickets_custom_fields ON tickets USING GIN (custom_fields jsonb_path_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tickets_custom_fields;
+ALTER TABLE tickets DROP COLUMN IF EXISTS custom_fields;
+DROP TABLE IF EXISTS custom_fields;
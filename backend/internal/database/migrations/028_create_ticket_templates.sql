@@ -0,0 +1,22 @@
+-- +goose Up
+-- Request templates per category. title_pattern may reference custom field
+-- values as {{key}}; required_fields lists custom field keys that tickets
+-- created from the template must fill in.
+CREATE TABLE IF NOT EXISTS ticket_templates (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            TEXT        NOT NULL,
+    category        TEXT        NOT NULL,
+    title_pattern   TEXT        NOT NULL DEFAULT '',
+    description     TEXT        NOT NULL DEFAULT '',
+    priority        TEXT        NULL,
+    required_fields TEXT[]      NOT NULL DEFAULT '{}',
+    position        INTEGER     NOT NULL DEFAULT 0,
+    active          BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_templates_category ON ticket_templates(category);
+
+-- +goose Down
+DROP TABLE IF EXISTS ticket_templates;
@@ -0,0 +1,54 @@
+-- +goose Up
+-- Users following a ticket. The creator and the assignee are added by a
+-- trigger so every write path (create, update, claim, bulk) keeps them;
+-- others follow themselves or are CC'd by staff.
+CREATE TABLE IF NOT EXISTS ticket_watchers (
+    ticket_id  UUID        NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source     TEXT        NOT NULL CHECK (source IN ('creator','assignee','follow','cc')),
+    added_by   UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (ticket_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_watchers_user ON ticket_watchers(user_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ticket_auto_watch()
+RETURNS trigger AS $$
+BEGIN
+  IF NEW.created_by IS NOT NULL AND (TG_OP = 'INSERT' OR NEW.created_by IS DISTINCT FROM OLD.created_by) THEN
+    INSERT INTO ticket_watchers (ticket_id, user_id, source)
+    VALUES (NEW.id, NEW.created_by, 'creator')
+    ON CONFLICT DO NOTHING;
+  END IF;
+  IF COALESCE(NEW.assignee, '') <> '' AND (TG_OP = 'INSERT' OR NEW.assignee IS DISTINCT FROM OLD.assignee) THEN
+    INSERT INTO ticket_watchers (ticket_id, user_id, source)
+    SELECT NEW.id, u.id, 'assignee' FROM users u WHERE u.id::text = NEW.assignee
+    ON CONFLICT DO NOTHING;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS ticket_auto_watch ON tickets;
+CREATE TRIGGER ticket_auto_watch
+AFTER INSERT OR UPDATE OF created_by, assignee ON tickets
+FOR EACH ROW
+EXECUTE FUNCTION ticket_auto_watch();
+
+INSERT INTO ticket_watchers (ticket_id, user_id, source, created_at)
+SELECT id, created_by, 'creator', created_at FROM tickets WHERE created_by IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ticket_watchers (ticket_id, user_id, source, created_at)
+SELECT t.id, u.id, 'assignee', t.created_at FROM tickets t JOIN users u ON u.id::text = t.assignee
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS ticket_auto_watch ON tickets;
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS ticket_auto_watch();
+-- +goose StatementEnd
+DROP TABLE IF EXISTS ticket_watchers;
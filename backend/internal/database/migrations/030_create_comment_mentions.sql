@@ -0,0 +1,24 @@
+-- +goose Up
+-- Users @-mentioned in comments. handle is the text after '@' as written.
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    comment_id UUID        NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    handle     TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user ON comment_mentions(user_id);
+
+-- Mentioned users become watchers
+ALTER TABLE ticket_watchers DROP CONSTRAINT IF EXISTS ticket_watchers_source_check;
+ALTER TABLE ticket_watchers ADD CONSTRAINT ticket_watchers_source_check
+    CHECK (source IN ('creator','assignee','follow','cc','mention'));
+
+-- +goose Down
+UPDATE ticket_watchers SET source = 'follow' WHERE source = 'mention';
+ALTER TABLE ticket_watchers DROP CONSTRAINT IF EXISTS ticket_watchers_source_check;
+ALTER TABLE ticket_watchers ADD CONSTRAINT ticket_watchers_source_check
+    CHECK (source IN ('creator','assignee','follow','cc'));
+
+DROP TABLE IF EXISTS comment_mentions;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- In-app notifications. Users opt out per type in notification_preferences;
+-- a type without a row is enabled.
+CREATE TABLE IF NOT EXISTS notifications (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       TEXT        NOT NULL CHECK (type IN ('assigned','commented','status_changed','mentioned')),
+    ticket_id  UUID        NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    actor_id   UUID        NULL REFERENCES users(id) ON DELETE SET NULL,
+    message    TEXT        NOT NULL DEFAULT '',
+    read_at    TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       TEXT        NOT NULL,
+    enabled    BOOLEAN     NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, type)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type AliasFormatHTTP struct {
+	svc *service.AliasFormatService
+}
+
+func NewAliasFormatHTTP(svc *service.AliasFormatService) *AliasFormatHTTP {
+	return &AliasFormatHTTP{svc: svc}
+}
+
+type aliasFormatDTO struct {
+	Prefix      string `json:"prefix"`
+	Category    string `json:"category"`
+	TeamID      string `json:"teamId"`
+	IncludeYear *bool  `json:"includeYear"`
+	YearlyReset *bool  `json:"yearlyReset"`
+	Padding     int    `json:"padding"`
+	Active      *bool  `json:"active"`
+}
+
+func (d aliasFormatDTO) toModel() *models.AliasFormat {
+	f := &models.AliasFormat{
+		Prefix:      d.Prefix,
+		Category:    d.Category,
+		TeamID:      d.TeamID,
+		IncludeYear: true,
+		YearlyReset: true,
+		Padding:     d.Padding,
+		Active:      true,
+	}
+	if d.IncludeYear != nil {
+		f.IncludeYear = *d.IncludeYear
+	}
+	if d.YearlyReset != nil {
+		f.YearlyReset = *d.YearlyReset
+	}
+	if d.Active != nil {
+		f.Active = *d.Active
+	}
+	return f
+}
+
+// GET /api/alias-formats
+func (h *AliasFormatHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context())
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/alias-formats
+func (h *AliasFormatHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in aliasFormatDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		f := in.toModel()
+		if err := h.svc.Create(r.Context(), f); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, f)
+	}
+}
+
+// PUT /api/alias-formats/{id}
+func (h *AliasFormatHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in aliasFormatDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		f := in.toModel()
+		f.ID = chi.URLParam(r, "id")
+		if err := h.svc.Update(r.Context(), f); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, f)
+	}
+}
+
+// DELETE /api/alias-formats/{id}
+func (h *AliasFormatHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
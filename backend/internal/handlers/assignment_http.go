@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type AssignmentHTTP struct {
+	svc *service.AssignmentService
+}
+
+func NewAssignmentHTTP(svc *service.AssignmentService) *AssignmentHTTP {
+	return &AssignmentHTTP{svc: svc}
+}
+
+type assignmentRuleDTO struct {
+	Name         string `json:"name"`
+	Position     int    `json:"position"`
+	Category     string `json:"category"`
+	Department   string `json:"department"`
+	Strategy     string `json:"strategy"`
+	TargetUserID string `json:"targetUserId"`
+	Active       *bool  `json:"active"`
+}
+
+func (d assignmentRuleDTO) toModel() *models.AssignmentRule {
+	r := &models.AssignmentRule{
+		Name:         d.Name,
+		Position:     d.Position,
+		Category:     d.Category,
+		Department:   d.Department,
+		Strategy:     d.Strategy,
+		TargetUserID: d.TargetUserID,
+		Active:       true,
+	}
+	if d.Active != nil {
+		r.Active = *d.Active
+	}
+	return r
+}
+
+// GET /api/assignment-rules
+func (h *AssignmentHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.ListRules(r.Context())
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/assignment-rules
+func (h *AssignmentHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in assignmentRuleDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		rule := in.toModel()
+		if err := h.svc.CreateRule(r.Context(), rule); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, rule)
+	}
+}
+
+// PUT /api/assignment-rules/{id}
+func (h *AssignmentHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in assignmentRuleDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		rule := in.toModel()
+		rule.ID = chi.URLParam(r, "id")
+		if err := h.svc.UpdateRule(r.Context(), rule); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, rule)
+	}
+}
+
+// DELETE /api/assignment-rules/{id}
+func (h *AssignmentHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.DeleteRule(r.Context(), chi.URLParam(r, "id")); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/middleware"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+// AttachmentHTTP exposes upload/list/download of ticket and comment files.
+type AttachmentHTTP struct {
+	svc *service.AttachmentService
+}
+
+func NewAttachmentHTTP(svc *service.AttachmentService) *AttachmentHTTP {
+	return &AttachmentHTTP{svc: svc}
+}
+
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/attachments
+// POST /api/tickets/{id}/comments/{commentId}/attachments
+// multipart/form-data with a single "file" part.
+// -----------------------------------------------------------------------------
+func (h *AttachmentHTTP) Upload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
+		}
+		commentID := chi.URLParam(r, "commentId")
+
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		// Allow some headroom over the file limit for multipart framing
+		r.Body = http.MaxBytesReader(w, r.Body, h.svc.MaxBytes()+1<<20)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, "expected multipart/form-data")
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				utils.Error(w, http.StatusBadRequest, "file is required")
+				return
+			}
+			if err != nil {
+				uploadError(w, err)
+				return
+			}
+			if part.FormName() != "file" {
+				_ = part.Close()
+				continue
+			}
+
+			a, err := h.svc.Upload(r.Context(), service.Actor{ID: uid, Role: role}, id, commentID, part.FileName(), part)
+			_ = part.Close()
+			if err != nil {
+				uploadError(w, err)
+				return
+			}
+			utils.JSON(w, http.StatusCreated, a)
+			return
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GET /api/tickets/{id}/attachments
+// -----------------------------------------------------------------------------
+func (h *AttachmentHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
+		}
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		items, err := h.svc.List(r.Context(), service.Actor{ID: uid, Role: role}, id)
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GET /api/tickets/{id}/attachments/{attachmentId}
+// Streams the file; same visibility rules as GET /api/tickets/{id}.
+// -----------------------------------------------------------------------------
+func (h *AttachmentHTTP) Download() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		attID := chi.URLParam(r, "attachmentId")
+		if id == "" || attID == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
+		}
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		a, rc, err := h.svc.Open(r.Context(), service.Actor{ID: uid, Role: role}, id, attID)
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", a.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(a.SizeBytes, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, rc)
+	}
+}
+
+// uploadError maps request-body limit errors to 413 and defers the rest to ticketError.
+func uploadError(w http.ResponseWriter, err error) {
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		utils.Error(w, http.StatusRequestEntityTooLarge, service.ErrAttachmentTooLarge.Error())
+		return
+	}
+	ticketError(w, err)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type CalendarHTTP struct {
+	svc *service.CalendarService
+}
+
+func NewCalendarHTTP(svc *service.CalendarService) *CalendarHTTP { return &CalendarHTTP{svc: svc} }
+
+type calendarDTO struct {
+	Name       string                        `json:"name"`
+	Timezone   string                        `json:"timezone"`
+	Hours      map[string][]models.TimeRange `json:"hours"`
+	Department string                        `json:"department"`
+	IsDefault  bool                          `json:"isDefault"`
+	Holidays   []models.Holiday              `json:"holidays"`
+}
+
+func (d calendarDTO) toModel() *models.BusinessCalendar {
+	return &models.BusinessCalendar{
+		Name:       d.Name,
+		Timezone:   d.Timezone,
+		Hours:      d.Hours,
+		Department: d.Department,
+		IsDefault:  d.IsDefault,
+		Holidays:   d.Holidays,
+	}
+}
+
+// GET /api/calendars
+func (h *CalendarHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context())
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/calendars
+func (h *CalendarHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in calendarDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		c := in.toModel()
+		if err := h.svc.Create(r.Context(), c); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, c)
+	}
+}
+
+// PUT /api/calendars/{id} (replaces hours and holidays)
+func (h *CalendarHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in calendarDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		c := in.toModel()
+		c.ID = chi.URLParam(r, "id")
+		if err := h.svc.Update(r.Context(), c); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, c)
+	}
+}
+
+// DELETE /api/calendars/{id}
+func (h *CalendarHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type CustomFieldHTTP struct {
+	svc *service.CustomFieldService
+}
+
+func NewCustomFieldHTTP(svc *service.CustomFieldService) *CustomFieldHTTP {
+	return &CustomFieldHTTP{svc: svc}
+}
+
+type customFieldDTO struct {
+	Key        string   `json:"key"`
+	Label      string   `json:"label"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options"`
+	Required   bool     `json:"required"`
+	Categories []string `json:"categories"`
+	Position   int      `json:"position"`
+	Active     *bool    `json:"active"`
+}
+
+func (d customFieldDTO) toModel() *models.CustomField {
+	return &models.CustomField{
+		Key:        d.Key,
+		Label:      d.Label,
+		Type:       d.Type,
+		Options:    d.Options,
+		Required:   d.Required,
+		Categories: d.Categories,
+		Position:   d.Position,
+		Active:     activeOrDefault(d.Active),
+	}
+}
+
+// customFieldError maps custom field service errors to HTTP statuses.
+func customFieldError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		utils.Error(w, http.StatusBadRequest, ve.Msg)
+	case errors.Is(err, service.ErrCustomFieldNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		utils.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GET /api/custom-fields?active=true
+func (h *CustomFieldHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		activeOnly, _ := strconv.ParseBool(r.URL.Query().Get("active"))
+		items, err := h.svc.List(r.Context(), activeOnly)
+		if err != nil {
+			customFieldError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/custom-fields
+func (h *CustomFieldHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in customFieldDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		f := in.toModel()
+		if err := h.svc.Create(r.Context(), f); err != nil {
+			customFieldError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, f)
+	}
+}
+
+// PUT /api/custom-fields/{id}
+func (h *CustomFieldHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in customFieldDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		f := in.toModel()
+		f.ID = chi.URLParam(r, "id")
+		if err := h.svc.Update(r.Context(), f); err != nil {
+			customFieldError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, f)
+	}
+}
+
+// DELETE /api/custom-fields/{id}
+func (h *CustomFieldHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			customFieldError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
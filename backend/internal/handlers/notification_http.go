@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type NotificationHTTP struct {
+	svc *service.NotificationService
+}
+
+func NewNotificationHTTP(svc *service.NotificationService) *NotificationHTTP {
+	return &NotificationHTTP{svc: svc}
+}
+
+// notificationError maps notification service errors to HTTP statuses.
+func notificationError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		utils.Error(w, http.StatusBadRequest, ve.Msg)
+	case errors.Is(err, service.ErrNotificationNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		utils.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GET /api/notifications?unread=true&limit=&offset=
+func (h *NotificationHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		unread, _ := strconv.ParseBool(qv.Get("unread"))
+		items, total, err := h.svc.List(r.Context(), actorFrom(r), unread,
+			utils.QueryInt(qv, "limit", 20), utils.QueryInt(qv, "offset", 0))
+		if err != nil {
+			notificationError(w, err)
+			return
+		}
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+	}
+}
+
+// GET /api/notifications/unread-count
+func (h *NotificationHTTP) UnreadCount() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n, err := h.svc.UnreadCount(r.Context(), actorFrom(r))
+		if err != nil {
+			notificationError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"count": n})
+	}
+}
+
+// POST /api/notifications/{id}/read
+func (h *NotificationHTTP) MarkRead() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.MarkRead(r.Context(), actorFrom(r), chi.URLParam(r, "id")); err != nil {
+			notificationError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /api/notifications/read-all
+func (h *NotificationHTTP) MarkAllRead() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n, err := h.svc.MarkAllRead(r.Context(), actorFrom(r))
+		if err != nil {
+			notificationError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"updated": n})
+	}
+}
+
+// GET /api/notifications/preferences
+// Returns: { "assigned": true, "commented": false, ... }
+func (h *NotificationHTTP) Preferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prefs, err := h.svc.Preferences(r.Context(), actorFrom(r))
+		if err != nil {
+			notificationError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, prefs)
+	}
+}
+
+// PUT /api/notifications/preferences  body: { "<type>": bool, ... }
+func (h *NotificationHTTP) SetPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]bool
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		prefs, err := h.svc.SetPreferences(r.Context(), actorFrom(r), in)
+		if err != nil {
+			notificationError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, prefs)
+	}
+}
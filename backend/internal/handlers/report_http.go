@@ -5,78 +5,49 @@ import (
 	"time"
 
 	"gh-ts/internal/repository"
+	"gh-ts/internal/service"
 	"gh-ts/internal/utils"
 )
 
 type ReportsHTTP struct {
-	repo repository.TicketRepository
+	repo     repository.TicketReportRepository
+	taxonomy *service.TaxonomyService
 }
 
-func NewReportsHTTP(r repository.TicketRepository) *ReportsHTTP { return &ReportsHTTP{repo: r} }
+func NewReportsHTTP(r repository.TicketReportRepository, taxonomy *service.TaxonomyService) *ReportsHTTP {
+	return &ReportsHTTP{repo: r, taxonomy: taxonomy}
+}
 
 // GET /api/reports/summary
 // Returns: { open, resolved7d, highCriticalOpen }
+// Open means not in a terminal status; highCriticalOpen counts open tickets
+// with a priority flagged as high.
 func (h *ReportsHTTP) Summary() http.HandlerFunc {
-	type adv interface {
-		CountByStatus(ctx interface{ Done() <-chan struct{} }, statuses []string, inclusive bool) (int, error)
-		CountResolvedSince(ctx interface{ Done() <-chan struct{} }, since time.Time) (int, error)
-		CountOpenByPriorities(ctx interface{ Done() <-chan struct{} }, prios []string) (int, error)
-	}
-
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Fast path if the concrete repo supports counters
-		if rr, ok := h.repo.(adv); ok {
-			open, err := rr.CountByStatus(r.Context(), []string{"Resolved", "Closed"}, false)
-			if err != nil {
-				utils.Error(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-
-			resolved7d, err := rr.CountResolvedSince(r.Context(), time.Now().Add(-7*24*time.Hour))
-			if err != nil {
-				utils.Error(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-
-			highCritOpen, err := rr.CountOpenByPriorities(r.Context(), []string{"High", "Critical"})
-			if err != nil {
-				utils.Error(w, http.StatusInternalServerError, err.Error())
-				return
-			}
+		tax, err := h.taxonomy.Get(r.Context())
+		if err != nil {
+			utils.Error(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 
-			utils.JSON(w, http.StatusOK, map[string]int{
-				"open":             open,
-				"resolved7d":       resolved7d,
-				"highCriticalOpen": highCritOpen,
-			})
+		open, err := h.repo.CountByStatus(r.Context(), tax.TerminalStatuses(), false)
+		if err != nil {
+			utils.Error(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		// Fallback (works with any repo): list & compute
-		items, err := h.repo.List(r.Context(), "", "", 1000, 0) // cap to 1000 to avoid heavy scans
+		resolved7d, err := h.repo.CountResolvedSince(r.Context(), time.Now().Add(-7*24*time.Hour))
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		now := time.Now()
-		open, resolved7d, highCritOpen := 0, 0, 0
-		for _, t := range items {
-			closed := t.Status == "Resolved" || t.Status == "Closed"
-			if !closed {
-				open++
-			}
-			updated := t.UpdatedAt
-			if updated.IsZero() {
-				updated = t.CreatedAt
-			}
-			if closed && now.Sub(updated) <= 7*24*time.Hour {
-				resolved7d++
-			}
-			if (t.Priority == "High" || t.Priority == "Critical") && !closed {
-				highCritOpen++
-			}
+		highCritOpen, err := h.repo.CountOpenByPriorities(r.Context(), tax.HighPriorities())
+		if err != nil {
+			utils.Error(w, http.StatusInternalServerError, err.Error())
+			return
 		}
+
 		utils.JSON(w, http.StatusOK, map[string]int{
 			"open":             open,
 			"resolved7d":       resolved7d,
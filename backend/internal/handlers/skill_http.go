@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type SkillHTTP struct {
+	svc *service.SkillService
+}
+
+func NewSkillHTTP(svc *service.SkillService) *SkillHTTP { return &SkillHTTP{svc: svc} }
+
+// skillError maps skill service errors to HTTP statuses.
+func skillError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		utils.Error(w, http.StatusBadRequest, ve.Msg)
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrSkillNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		utils.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GET /api/users/{id}/skills
+func (h *SkillHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			skillError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// PUT /api/users/{id}/skills/{skill}
+// Body: { "proficiency": 1..5 } (defaults to 3)
+func (h *SkillHTTP) Set() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Proficiency int `json:"proficiency"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				utils.Error(w, http.StatusBadRequest, "invalid json")
+				return
+			}
+		}
+		sk := &models.UserSkill{
+			UserID:      chi.URLParam(r, "id"),
+			Skill:       chi.URLParam(r, "skill"),
+			Proficiency: in.Proficiency,
+		}
+		if err := h.svc.Set(r.Context(), sk); err != nil {
+			skillError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, sk)
+	}
+}
+
+// DELETE /api/users/{id}/skills/{skill}
+func (h *SkillHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "skill")); err != nil {
+			skillError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type SLAHTTP struct {
+	svc *service.SLAService
+}
+
+func NewSLAHTTP(svc *service.SLAService) *SLAHTTP { return &SLAHTTP{svc: svc} }
+
+type slaPolicyDTO struct {
+	Name                 string `json:"name"`
+	Priority             string `json:"priority"`
+	Category             string `json:"category"`
+	Department           string `json:"department"`
+	FirstResponseMinutes int    `json:"firstResponseMinutes"`
+	ResolutionMinutes    int    `json:"resolutionMinutes"`
+	Active               *bool  `json:"active"`
+}
+
+func (d slaPolicyDTO) toModel() *models.SLAPolicy {
+	p := &models.SLAPolicy{
+		Name:                 d.Name,
+		Priority:             d.Priority,
+		Category:             d.Category,
+		Department:           d.Department,
+		FirstResponseMinutes: d.FirstResponseMinutes,
+		ResolutionMinutes:    d.ResolutionMinutes,
+		Active:               true,
+	}
+	if d.Active != nil {
+		p.Active = *d.Active
+	}
+	return p
+}
+
+// GET /api/sla-policies
+func (h *SLAHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.ListPolicies(r.Context())
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/sla-policies
+func (h *SLAHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in slaPolicyDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		p := in.toModel()
+		if err := h.svc.CreatePolicy(r.Context(), p); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, p)
+	}
+}
+
+// PUT /api/sla-policies/{id}
+func (h *SLAHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in slaPolicyDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		p := in.toModel()
+		p.ID = chi.URLParam(r, "id")
+		if err := h.svc.UpdatePolicy(r.Context(), p); err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, p)
+	}
+}
+
+// DELETE /api/sla-policies/{id}
+func (h *SLAHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.DeletePolicy(r.Context(), chi.URLParam(r, "id")); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type TagHTTP struct {
+	svc *service.TagService
+}
+
+func NewTagHTTP(svc *service.TagService) *TagHTTP {
+	return &TagHTTP{svc: svc}
+}
+
+type tagDTO struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// tagError maps tag service errors to HTTP statuses.
+func tagError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrTagNotFound) {
+		utils.Error(w, http.StatusNotFound, "not found")
+		return
+	}
+	ticketError(w, err)
+}
+
+// GET /api/tags
+func (h *TagHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context())
+		if err != nil {
+			tagError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/tags
+func (h *TagHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in tagDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := &models.Tag{Name: in.Name, Color: in.Color}
+		if err := h.svc.Create(r.Context(), actorFrom(r), t); err != nil {
+			tagError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, t)
+	}
+}
+
+// PUT /api/tags/{id}
+func (h *TagHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in tagDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := &models.Tag{ID: chi.URLParam(r, "id"), Name: in.Name, Color: in.Color}
+		if err := h.svc.Update(r.Context(), t); err != nil {
+			tagError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// DELETE /api/tags/{id}
+func (h *TagHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			tagError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /api/tickets/{id}/tags  {"tags": ["vpn", "vip"]}
+func (h *TagHTTP) AddToTicket() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		tags, err := h.svc.AddToTicket(r.Context(), actorFrom(r), chi.URLParam(r, "id"), in.Tags)
+		if err != nil {
+			tagError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"tags": tags})
+	}
+}
+
+// DELETE /api/tickets/{id}/tags/{tag}
+func (h *TagHTTP) RemoveFromTicket() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.RemoveFromTicket(r.Context(), actorFrom(r), chi.URLParam(r, "id"), chi.URLParam(r, "tag")); err != nil {
+			tagError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GET /api/reports/tags
+// Returns: { items: [{ tag, open, total }] }
+func (h *TagHTTP) Report() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.Counts(r.Context())
+		if err != nil {
+			tagError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type TaxonomyHTTP struct {
+	svc *service.TaxonomyService
+}
+
+func NewTaxonomyHTTP(svc *service.TaxonomyService) *TaxonomyHTTP {
+	return &TaxonomyHTTP{svc: svc}
+}
+
+type statusDTO struct {
+	Name     string   `json:"name"`
+	Position int      `json:"position"`
+	Color    string   `json:"color"`
+	Terminal bool     `json:"terminal"`
+	Next     []string `json:"next"`
+	Active   *bool    `json:"active"`
+}
+
+type priorityDTO struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	Color    string `json:"color"`
+	Default  bool   `json:"default"`
+	High     bool   `json:"high"`
+	Active   *bool  `json:"active"`
+}
+
+type categoryDTO struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	Color    string `json:"color"`
+	Active   *bool  `json:"active"`
+}
+
+// activeOrDefault treats a missing "active" as true.
+func activeOrDefault(b *bool) bool {
+	return b == nil || *b
+}
+
+// taxonomyError maps taxonomy service errors to HTTP statuses.
+func taxonomyError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		utils.Error(w, http.StatusBadRequest, ve.Msg)
+	case errors.Is(err, service.ErrTaxonomyNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		utils.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GET /api/taxonomy
+// Returns: { statuses, priorities, categories } in position order
+func (h *TaxonomyHTTP) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.svc.Get(r.Context())
+		if err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// POST /api/taxonomy/statuses, PUT /api/taxonomy/statuses/{id}
+func (h *TaxonomyHTTP) SaveStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in statusDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		st := &models.TicketStatus{
+			ID:       chi.URLParam(r, "id"),
+			Name:     in.Name,
+			Position: in.Position,
+			Color:    in.Color,
+			Terminal: in.Terminal,
+			Next:     in.Next,
+			Active:   activeOrDefault(in.Active),
+		}
+		save, code := h.svc.CreateStatus, http.StatusCreated
+		if st.ID != "" {
+			save, code = h.svc.UpdateStatus, http.StatusOK
+		}
+		if err := save(r.Context(), st); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		utils.JSON(w, code, st)
+	}
+}
+
+// DELETE /api/taxonomy/statuses/{id}
+func (h *TaxonomyHTTP) DeleteStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.DeleteStatus(r.Context(), chi.URLParam(r, "id")); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /api/taxonomy/priorities, PUT /api/taxonomy/priorities/{id}
+func (h *TaxonomyHTTP) SavePriority() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in priorityDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		p := &models.TicketPriority{
+			ID:       chi.URLParam(r, "id"),
+			Name:     in.Name,
+			Position: in.Position,
+			Color:    in.Color,
+			Default:  in.Default,
+			High:     in.High,
+			Active:   activeOrDefault(in.Active),
+		}
+		save, code := h.svc.CreatePriority, http.StatusCreated
+		if p.ID != "" {
+			save, code = h.svc.UpdatePriority, http.StatusOK
+		}
+		if err := save(r.Context(), p); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		utils.JSON(w, code, p)
+	}
+}
+
+// DELETE /api/taxonomy/priorities/{id}
+func (h *TaxonomyHTTP) DeletePriority() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.DeletePriority(r.Context(), chi.URLParam(r, "id")); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /api/taxonomy/categories, PUT /api/taxonomy/categories/{id}
+func (h *TaxonomyHTTP) SaveCategory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in categoryDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		c := &models.TicketCategory{
+			ID:       chi.URLParam(r, "id"),
+			Name:     in.Name,
+			Position: in.Position,
+			Color:    in.Color,
+			Active:   activeOrDefault(in.Active),
+		}
+		save, code := h.svc.CreateCategory, http.StatusCreated
+		if c.ID != "" {
+			save, code = h.svc.UpdateCategory, http.StatusOK
+		}
+		if err := save(r.Context(), c); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		utils.JSON(w, code, c)
+	}
+}
+
+// DELETE /api/taxonomy/categories/{id}
+func (h *TaxonomyHTTP) DeleteCategory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.DeleteCategory(r.Context(), chi.URLParam(r, "id")); err != nil {
+			taxonomyError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
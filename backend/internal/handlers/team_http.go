@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/middleware"
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type TeamHTTP struct {
+	svc *service.TeamService
+}
+
+func NewTeamHTTP(svc *service.TeamService) *TeamHTTP { return &TeamHTTP{svc: svc} }
+
+type teamDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// teamError maps team service errors to HTTP statuses.
+func teamError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrTeamNotFound), errors.Is(err, service.ErrMemberNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		ticketError(w, err)
+	}
+}
+
+func actorFrom(r *http.Request) service.Actor {
+	uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+	role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+	return service.Actor{ID: uid, Role: role}
+}
+
+// GET /api/teams
+func (h *TeamHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context())
+		if err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// GET /api/teams/{id} (with members)
+func (h *TeamHTTP) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// POST /api/teams
+func (h *TeamHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in teamDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := &models.Team{Name: in.Name, Description: in.Description}
+		if err := h.svc.Create(r.Context(), t); err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, t)
+	}
+}
+
+// PUT /api/teams/{id}
+func (h *TeamHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in teamDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := &models.Team{ID: chi.URLParam(r, "id"), Name: in.Name, Description: in.Description}
+		if err := h.svc.Update(r.Context(), t); err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// DELETE /api/teams/{id}
+func (h *TeamHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			teamError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// PUT /api/teams/{id}/members/{userId}
+// Body: { "lead": bool } (optional)
+func (h *TeamHTTP) SetMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Lead bool `json:"lead"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				utils.Error(w, http.StatusBadRequest, "invalid json")
+				return
+			}
+		}
+		t, err := h.svc.SetMember(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "userId"), in.Lead)
+		if err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// DELETE /api/teams/{id}/members/{userId}
+func (h *TeamHTTP) RemoveMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.RemoveMember(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "userId")); err != nil {
+			teamError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GET /api/queues/{id}/tickets?unassigned=&status=&priority=&sla=&all=&sort=&order=&limit=&offset=
+// Open tickets in the team queue unless all=true. Admins and team members only.
+// -----------------------------------------------------------------------------
+func (h *TeamHTTP) Queue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		unassigned, _ := strconv.ParseBool(qv.Get("unassigned"))
+		all, _ := strconv.ParseBool(qv.Get("all"))
+		f := repository.TicketFilter{
+			Q:          strings.TrimSpace(qv.Get("q")),
+			Status:     strings.TrimSpace(qv.Get("status")),
+			Priority:   strings.TrimSpace(qv.Get("priority")),
+			Category:   strings.TrimSpace(qv.Get("category")),
+			Assignee:   strings.TrimSpace(qv.Get("assignee")),
+			SLA:        strings.TrimSpace(qv.Get("sla")),
+			Unassigned: unassigned,
+			OpenOnly:   !all,
+			Limit:      utils.QueryInt(qv, "limit", 10),
+			Offset:     utils.QueryInt(qv, "offset", 0),
+			Sort:       qv.Get("sort"),
+			Order:      qv.Get("order"),
+		}
+		items, total, err := h.svc.Queue(r.Context(), actorFrom(r), chi.URLParam(r, "id"), f)
+		if err != nil {
+			teamError(w, err)
+			return
+		}
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// POST /api/queues/{id}/rebalance
+// Body: { "from": "<userId>" } (optional; default: distribute unassigned)
+// Admins and supervisors in the team only.
+// -----------------------------------------------------------------------------
+func (h *TeamHTTP) Rebalance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			From string `json:"from"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				utils.Error(w, http.StatusBadRequest, "invalid json")
+				return
+			}
+		}
+		moves, err := h.svc.Rebalance(r.Context(), actorFrom(r), chi.URLParam(r, "id"), strings.TrimSpace(in.From))
+		if err != nil {
+			teamError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": moves, "total": len(moves)})
+	}
+}
@@ -9,127 +9,181 @@ import (
 	"strings"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/google/uuid"
 
 	"gh-ts/internal/middleware"
 	"gh-ts/internal/models"
 	"gh-ts/internal/repository"
+	"gh-ts/internal/service"
 	"gh-ts/internal/utils"
 )
 
-// TicketHTTP wires HTTP endpoints to repositories.
+// TicketHTTP wires HTTP endpoints to repositories and the ticket service.
 type TicketHTTP struct {
 	tickets repository.TicketRepository
-	users   repository.UserRepository
+	svc     *service.TicketService
 }
 
-var (
-	allowedTicketStatuses = map[string]struct{}{
-		"New":         {},
-		"Open":        {},
-		"In Progress": {},
-		"Pending":     {},
-		"Resolved":    {},
-		"Closed":      {},
-	}
-	allowedTicketPriorities = map[string]struct{}{
-		"Low":      {},
-		"Medium":   {},
-		"High":     {},
-		"Critical": {},
-	}
-	allowedTicketCategories = map[string]struct{}{
-		"Software": {},
-		"Hardware": {},
-		"Network":  {},
-		"Access":   {},
-		"General":  {},
-	}
-	allowedAssigneeRoles = map[string]struct{}{
-		"admin":      {},
-		"agent":      {},
-		"supervisor": {},
-	}
-)
+func NewTicketHTTP(tickets repository.TicketRepository, svc *service.TicketService) *TicketHTTP {
+	return &TicketHTTP{tickets: tickets, svc: svc}
+}
 
-func NewTicketHTTP(tickets repository.TicketRepository, users repository.UserRepository) *TicketHTTP {
-	return &TicketHTTP{tickets: tickets, users: users}
+// ticketError maps service errors to HTTP statuses.
+func ticketError(w http.ResponseWriter, err error) {
+	status, msg := ticketErrorStatus(err)
+	utils.Error(w, status, msg)
 }
 
-// -----------------------------------------------------------------------------
-// Optional repo capability for DB-based default admin lookup (kept for future).
-// -----------------------------------------------------------------------------
-type defaultAdminFinder interface {
-	FirstActiveAdminID(ctx context.Context) (string, error)
+// ticketErrorStatus returns the HTTP status and message for a service error.
+func ticketErrorStatus(err error) (int, string) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		return http.StatusBadRequest, ve.Msg
+	case errors.Is(err, service.ErrTicketNotFound), errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrLinkNotFound),
+		errors.Is(err, service.ErrSLAPolicyNotFound),
+		errors.Is(err, service.ErrCalendarNotFound),
+		errors.Is(err, service.ErrAssignmentRuleNotFound),
+		errors.Is(err, service.ErrAliasFormatNotFound):
+		return http.StatusNotFound, "not found"
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge, err.Error()
+	case errors.Is(err, service.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType, err.Error()
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrAlreadyClaimed):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, service.ErrVersionConflict):
+		return http.StatusPreconditionFailed, "ticket was modified by someone else; reload and retry"
+	case errors.Is(err, service.ErrBulkAborted):
+		return http.StatusFailedDependency, err.Error()
+	default:
+		return http.StatusInternalServerError, err.Error()
+	}
 }
 
-func (h *TicketHTTP) getDefaultAdminID(ctx context.Context) (string, error) {
-	// Prefer the users repo (which we have)
-	if h.users != nil {
-		if id, err := h.users.FirstActiveAdminID(ctx); err == nil && strings.TrimSpace(id) != "" {
-			return id, nil
+// ResolveID is middleware for /api/tickets/{id} routes: it accepts a ticket
+// alias (e.g. TKT-2025-00042, any case) in place of the UUID and rewrites
+// the {id} URL parameter so downstream handlers always see the UUID.
+func (h *TicketHTTP) ResolveID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		raw := chi.URLParam(r, "id")
+		id, err := h.svc.ResolveID(r.Context(), raw)
+		if err != nil {
+			ticketError(w, err)
+			return
 		}
-	}
-	// As a fallback, if ticket repo happened to implement it (unlikely)
-	if af, ok := any(h.tickets).(defaultAdminFinder); ok {
-		if id, err := af.FirstActiveAdminID(ctx); err == nil && strings.TrimSpace(id) != "" {
-			return id, nil
+		if id != raw {
+			for i, k := range rctx.URLParams.Keys {
+				if k == "id" {
+					rctx.URLParams.Values[i] = id
+				}
+			}
 		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ticketETag derives the ETag from the row version. It is weak because the
+// body varies by role (internal notes are filtered for end users).
+func ticketETag(t *models.Ticket) string {
+	return `W/"` + strconv.Itoa(t.Version) + `"`
+}
+
+// ifMatchVersion parses an If-Match header produced by ticketETag. It returns
+// 0 when the header is absent or "*", and ok=false if it cannot match.
+func ifMatchVersion(r *http.Request) (version int, ok bool) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, true
+	}
+	v = strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, false
 	}
-	return "", context.DeadlineExceeded // sentinel "not found"
+	return n, true
 }
 
 // -----------------------------------------------------------------------------
-// GET /api/tickets ... (unchanged list logic, just swapped h.repo -> h.tickets)
+// GET /api/tickets?q=&status=&priority=&category=&assignee=&team=&unassigned=&watching=&sla=&sort=&order=&limit=&offset=
 // -----------------------------------------------------------------------------
 func (h *TicketHTTP) List() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		qv := r.URL.Query()
-		q := strings.TrimSpace(qv.Get("q"))
-		status := strings.TrimSpace(qv.Get("status"))
-		priority := strings.TrimSpace(qv.Get("priority"))
-		category := strings.TrimSpace(qv.Get("category"))
-		assignee := strings.TrimSpace(qv.Get("assignee"))
-		limit := utils.QueryInt(qv, "limit", 10)
-		offset := utils.QueryInt(qv, "offset", 0)
-		sort := qv.Get("sort")
-		order := qv.Get("order")
+		unassigned, _ := strconv.ParseBool(qv.Get("unassigned"))
+		f := repository.TicketFilter{
+			Q:        strings.TrimSpace(qv.Get("q")),
+			Status:   strings.TrimSpace(qv.Get("status")),
+			Priority: strings.TrimSpace(qv.Get("priority")),
+			Category: strings.TrimSpace(qv.Get("category")),
+			Assignee: strings.TrimSpace(qv.Get("assignee")),
+			SLA:      strings.TrimSpace(qv.Get("sla")),
+			TeamID:   strings.TrimSpace(qv.Get("team")),
+			// Unassigned queue: open tickets nobody has claimed yet
+			Unassigned: unassigned,
+			OpenOnly:   unassigned,
+			Tags:       utils.QueryList(qv, "tags"),
+			TagMatch:   qv.Get("tagMatch"),
+			Limit:      utils.QueryInt(qv, "limit", 10),
+			Offset:     utils.QueryInt(qv, "offset", 0),
+			Sort:       qv.Get("sort"),
+			Order:      qv.Get("order"),
+		}
+
+		// Custom field filters: ?cf.<key>=<value>
+		cf := map[string]string{}
+		for k, v := range qv {
+			if key, ok := strings.CutPrefix(k, "cf."); ok && len(v) > 0 {
+				cf[key] = v[0]
+			}
+		}
+		var err error
+		if f.CustomFields, err = h.svc.CustomFieldFilter(r.Context(), cf); err != nil {
+			ticketError(w, err)
+			return
+		}
 
 		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
 		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
 
+		// "Tickets I'm watching"
+		if watching, _ := strconv.ParseBool(qv.Get("watching")); watching {
+			if uid == "" {
+				utils.Error(w, http.StatusUnauthorized, "not authenticated")
+				return
+			}
+			f.WatcherID = uid
+		}
+
 		type adv interface {
-			ListAdv(ctx context.Context, q, status, priority, category, assignee, sort, order string, limit, offset int) ([]models.Ticket, error)
-			CountAdv(ctx context.Context, q, status, priority, category, assignee string) (int, error)
+			ListAdv(ctx context.Context, f repository.TicketFilter) ([]models.Ticket, error)
+			CountAdv(ctx context.Context, f repository.TicketFilter) (int, error)
 		}
 		if ar, ok := h.tickets.(adv); ok {
-			items, err := ar.ListAdv(r.Context(), q, status, priority, category, assignee, sort, order, limit, offset)
+			// End users only see their own tickets (filtered in SQL so totals are right)
+			if role == "end_user" && uid != "" {
+				f.CreatedBy = uid
+			}
+			items, err := ar.ListAdv(r.Context(), f)
 			if err != nil {
 				utils.Error(w, http.StatusInternalServerError, err.Error())
 				return
 			}
-			total, err := ar.CountAdv(r.Context(), q, status, priority, category, assignee)
+			total, err := ar.CountAdv(r.Context(), f)
 			if err != nil {
 				utils.Error(w, http.StatusInternalServerError, err.Error())
 				return
 			}
-			if role == "end_user" && uid != "" {
-				filtered := make([]models.Ticket, 0, len(items))
-				for _, t := range items {
-					if t.CreatedBy == uid {
-						filtered = append(filtered, t)
-					}
-				}
-				items = filtered
-				total = len(filtered)
-			}
 			w.Header().Set("X-Total-Count", strconv.Itoa(total))
 			utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
 			return
 		}
 
 		// legacy
-		items, err := h.tickets.List(r.Context(), q, status, limit, offset)
+		items, err := h.tickets.List(r.Context(), f.Q, f.Status, f.Limit, f.Offset)
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, err.Error())
 			return
@@ -173,14 +227,16 @@ func (h *TicketHTTP) Get() http.HandlerFunc {
 			utils.Error(w, http.StatusForbidden, "forbidden")
 			return
 		}
+		t.Comments = service.VisibleComments(role, t.Comments)
+		t.Links = service.VisibleLinks(service.Actor{ID: uid, Role: role}, t.Links)
+		w.Header().Set("ETag", ticketETag(t))
 		utils.JSON(w, http.StatusOK, t)
 	}
 }
 
 // -----------------------------------------------------------------------------
 // POST /api/tickets
-// If creator is end_user → ALWAYS auto-assign to first active admin from DB.
-// (If none available → 500 with a clear message.)
+// Validation and auto-assignment live in service.TicketService.Create.
 // -----------------------------------------------------------------------------
 func (h *TicketHTTP) Create() http.HandlerFunc {
 	type inDTO struct {
@@ -190,6 +246,10 @@ func (h *TicketHTTP) Create() http.HandlerFunc {
 		Priority    string `json:"priority"`
 		Department  string `json:"department"`
 		Assignee    string `json:"assignee"`
+		TeamID      string `json:"teamId"`
+
+		CustomFields map[string]any `json:"customFields"`
+		TemplateID   string         `json:"templateId"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		var in inDTO
@@ -197,11 +257,6 @@ func (h *TicketHTTP) Create() http.HandlerFunc {
 			utils.Error(w, http.StatusBadRequest, "invalid json")
 			return
 		}
-		in.Title = strings.TrimSpace(in.Title)
-		if in.Title == "" {
-			utils.Error(w, http.StatusBadRequest, "title is required")
-			return
-		}
 
 		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
 		if uid == "" {
@@ -210,73 +265,31 @@ func (h *TicketHTTP) Create() http.HandlerFunc {
 		}
 		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
 
-		assignee := strings.TrimSpace(in.Assignee)
-
-		// Hard rule: end users' tickets must be assigned to an admin
-		if role == "end_user" {
-			adminID, err := h.getDefaultAdminID(r.Context())
-			if err != nil || strings.TrimSpace(adminID) == "" {
-				utils.Error(w, http.StatusInternalServerError, "no active admin available for assignment")
-				return
-			}
-			assignee = adminID
-		} else if role == "admin" && assignee == "" {
-			assignee = uid
-		}
-
-		priority := strings.TrimSpace(in.Priority)
-		if priority == "" {
-			priority = "Low"
-		}
-		if _, ok := allowedTicketPriorities[priority]; !ok {
-			utils.Error(w, http.StatusBadRequest, "invalid priority")
-			return
-		}
-
-		category := strings.TrimSpace(in.Category)
-		if category != "" {
-			if _, ok := allowedTicketCategories[category]; !ok {
-				utils.Error(w, http.StatusBadRequest, "invalid category")
-				return
-			}
-		}
-
-		if err := h.validateAssignee(r.Context(), assignee); err != nil {
-			utils.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		t := &models.Ticket{
+		created, err := h.svc.Create(r.Context(), service.Actor{ID: uid, Role: role}, service.TicketInput{
 			Title:       in.Title,
-			Description: strings.TrimSpace(in.Description),
-			Category:    category,
-			Priority:    priority,
-			Status:      "New",
-			Assignee:    assignee,
-			Department:  strings.TrimSpace(in.Department),
-			CreatedBy:   uid,
-		}
-
-		if err := h.tickets.Create(r.Context(), t); err != nil {
-			utils.Error(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		created, err := h.tickets.Get(r.Context(), t.ID)
+			Description: in.Description,
+			Category:    in.Category,
+			Priority:    in.Priority,
+			Department:  in.Department,
+			Assignee:    in.Assignee,
+			TeamID:      in.TeamID,
+
+			CustomFields: in.CustomFields,
+			TemplateID:   in.TemplateID,
+		})
 		if err != nil {
-			utils.Error(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		if created == nil {
-			utils.Error(w, http.StatusInternalServerError, "ticket not found after creation")
+			ticketError(w, err)
 			return
 		}
+		w.Header().Set("ETag", ticketETag(created))
 		utils.JSON(w, http.StatusCreated, created)
 	}
 }
 
 // -----------------------------------------------------------------------------
 // PATCH /api/tickets/{id}
+// Status changes must follow the lifecycle (409 on illegal moves).
+// Honors If-Match with the ETag from GET (412 if the ticket changed).
 // -----------------------------------------------------------------------------
 func (h *TicketHTTP) Update() http.HandlerFunc {
 	type inDTO struct {
@@ -287,6 +300,10 @@ func (h *TicketHTTP) Update() http.HandlerFunc {
 		Status      *string `json:"status"`
 		Assignee    *string `json:"assignee"`
 		Department  *string `json:"department"`
+		TeamID      *string `json:"teamId"`
+
+		CustomFields         map[string]any `json:"customFields"`
+		OverrideOpenChildren bool           `json:"overrideOpenChildren"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
@@ -297,18 +314,60 @@ func (h *TicketHTTP) Update() http.HandlerFunc {
 			return
 		}
 
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			utils.Error(w, http.StatusBadRequest, "missing id")
 			return
 		}
 
+		version, ok := ifMatchVersion(r)
+		if !ok {
+			utils.Error(w, http.StatusPreconditionFailed, "If-Match does not match any ticket version")
+			return
+		}
+
 		var in inDTO
 		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
 			utils.Error(w, http.StatusBadRequest, "invalid json")
 			return
 		}
 
+		updated, err := h.svc.Update(r.Context(), service.Actor{ID: uid, Role: role}, id, service.TicketPatch{
+			Title:       in.Title,
+			Description: in.Description,
+			Category:    in.Category,
+			Priority:    in.Priority,
+			Status:      in.Status,
+			Assignee:    in.Assignee,
+			Department:  in.Department,
+			TeamID:      in.TeamID,
+			Version:     version,
+
+			CustomFields:         in.CustomFields,
+			OverrideOpenChildren: in.OverrideOpenChildren,
+		})
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.Header().Set("ETag", ticketETag(updated))
+		utils.JSON(w, http.StatusOK, updated)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GET /api/tickets/{id}/history
+// Same visibility rules as GET /api/tickets/{id}.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) History() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
+		}
 		t, err := h.tickets.Get(r.Context(), id)
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, err.Error())
@@ -318,80 +377,77 @@ func (h *TicketHTTP) Update() http.HandlerFunc {
 			utils.Error(w, http.StatusNotFound, "not found")
 			return
 		}
-
-		if in.Title != nil {
-			t.Title = strings.TrimSpace(*in.Title)
-		}
-		if in.Description != nil {
-			t.Description = strings.TrimSpace(*in.Description)
-		}
-		if in.Category != nil {
-			category := strings.TrimSpace(*in.Category)
-			if category != "" {
-				if _, ok := allowedTicketCategories[category]; !ok {
-					utils.Error(w, http.StatusBadRequest, "invalid category")
-					return
-				}
-			}
-			t.Category = category
-		}
-		if in.Priority != nil {
-			priority := strings.TrimSpace(*in.Priority)
-			if _, ok := allowedTicketPriorities[priority]; !ok {
-				utils.Error(w, http.StatusBadRequest, "invalid priority")
-				return
-			}
-			t.Priority = priority
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+		if role == "end_user" && uid != "" && t.CreatedBy != uid {
+			utils.Error(w, http.StatusForbidden, "forbidden")
+			return
 		}
-		if in.Status != nil {
-			status := strings.TrimSpace(*in.Status)
-			if _, ok := allowedTicketStatuses[status]; !ok {
-				utils.Error(w, http.StatusBadRequest, "invalid status")
-				return
-			}
-			t.Status = status
+
+		events, err := h.svc.History(r.Context(), t.ID)
+		if err != nil {
+			utils.Error(w, http.StatusInternalServerError, err.Error())
+			return
 		}
-		if in.Assignee != nil {
-			assignee := strings.TrimSpace(*in.Assignee)
-			if err := h.validateAssignee(r.Context(), assignee); err != nil {
-				utils.Error(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			t.Assignee = assignee
+		utils.JSON(w, http.StatusOK, map[string]any{"items": events, "total": len(events)})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/comments
+// Body: { text, internal } — internal notes are staff-only.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) AddComment() http.HandlerFunc {
+	type inDTO struct {
+		Text     string `json:"text"`
+		Internal bool   `json:"internal"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
 		}
-		if in.Department != nil {
-			t.Department = strings.TrimSpace(*in.Department)
+		var in inDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
 		}
 
-		if err := h.tickets.Update(r.Context(), t); err != nil {
-			utils.Error(w, http.StatusInternalServerError, err.Error())
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		if _, err := h.svc.AddComment(r.Context(), service.Actor{ID: uid, Role: role}, id, in.Text, in.Internal); err != nil {
+			ticketError(w, err)
 			return
 		}
-
-		// Fetch the updated ticket with assignee name/email populated via JOIN
-		updated, err := h.tickets.Get(r.Context(), t.ID)
+		t, err := h.tickets.Get(r.Context(), id)
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		if updated == nil {
-			utils.Error(w, http.StatusInternalServerError, "ticket not found after update")
+		if t == nil {
+			utils.Error(w, http.StatusNotFound, "not found")
 			return
 		}
-		utils.JSON(w, http.StatusOK, updated)
+		t.Comments = service.VisibleComments(role, t.Comments)
+		t.Links = service.VisibleLinks(service.Actor{ID: uid, Role: role}, t.Links)
+		utils.JSON(w, http.StatusOK, t)
 	}
 }
 
 // -----------------------------------------------------------------------------
-// POST /api/tickets/{id}/comments
+// PATCH /api/tickets/{id}/comments/{commentId}
+// Authors may edit within service.CommentEditWindow; admins at any time.
 // -----------------------------------------------------------------------------
-func (h *TicketHTTP) AddComment() http.HandlerFunc {
+func (h *TicketHTTP) UpdateComment() http.HandlerFunc {
 	type inDTO struct {
 		Text string `json:"text"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
-		if id == "" {
+		commentID := chi.URLParam(r, "commentId")
+		if id == "" || commentID == "" {
 			utils.Error(w, http.StatusBadRequest, "missing id")
 			return
 		}
@@ -400,51 +456,253 @@ func (h *TicketHTTP) AddComment() http.HandlerFunc {
 			utils.Error(w, http.StatusBadRequest, "invalid json")
 			return
 		}
-		in.Text = strings.TrimSpace(in.Text)
-		if in.Text == "" {
-			utils.Error(w, http.StatusBadRequest, "text is required")
+
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		c, err := h.svc.UpdateComment(r.Context(), service.Actor{ID: uid, Role: role}, id, commentID, in.Text)
+		if err != nil {
+			ticketError(w, err)
 			return
 		}
+		utils.JSON(w, http.StatusOK, c)
+	}
+}
 
-		if _, err := h.tickets.AddComment(r.Context(), id, in.Text); err != nil {
-			utils.Error(w, http.StatusInternalServerError, err.Error())
+// -----------------------------------------------------------------------------
+// DELETE /api/tickets/{id}/comments/{commentId}
+// Soft delete; same permissions as editing.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) DeleteComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		commentID := chi.URLParam(r, "commentId")
+		if id == "" || commentID == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
 			return
 		}
-		t, err := h.tickets.Get(r.Context(), id)
+
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		if err := h.svc.DeleteComment(r.Context(), service.Actor{ID: uid, Role: role}, id, commentID); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GET /api/tickets/{id}/comments/{commentId}/revisions (staff only)
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) CommentRevisions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		commentID := chi.URLParam(r, "commentId")
+		if id == "" || commentID == "" {
+			utils.Error(w, http.StatusBadRequest, "missing id")
+			return
+		}
+
+		role, _ := utils.GetString(r.Context(), middleware.CtxRole)
+		uid, _ := utils.GetString(r.Context(), middleware.CtxUserID)
+
+		revs, err := h.svc.CommentRevisions(r.Context(), service.Actor{ID: uid, Role: role}, id, commentID)
 		if err != nil {
-			utils.Error(w, http.StatusInternalServerError, err.Error())
+			ticketError(w, err)
 			return
 		}
-		if t == nil {
-			utils.Error(w, http.StatusNotFound, "not found")
+		utils.JSON(w, http.StatusOK, map[string]any{"items": revs, "total": len(revs)})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/claim
+// Assigns the caller if the ticket is unassigned (409 if someone got there first).
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) Claim() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.svc.Claim(r.Context(), actorFrom(r), chi.URLParam(r, "id"))
+		if err != nil {
+			ticketError(w, err)
 			return
 		}
+		w.Header().Set("ETag", ticketETag(t))
 		utils.JSON(w, http.StatusOK, t)
 	}
 }
 
-func (h *TicketHTTP) validateAssignee(ctx context.Context, assignee string) error {
-	if strings.TrimSpace(assignee) == "" {
-		return nil
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/release
+// Returns the ticket to its queue.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) Release() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.svc.Release(r.Context(), actorFrom(r), chi.URLParam(r, "id"))
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.Header().Set("ETag", ticketETag(t))
+		utils.JSON(w, http.StatusOK, t)
 	}
+}
 
-	if _, err := uuid.Parse(strings.TrimSpace(assignee)); err != nil {
-		return errors.New("invalid assignee id")
+// -----------------------------------------------------------------------------
+// POST /api/tickets/bulk
+// Body: { "ids": [...] | "filter": {...}, "changes": {...}, "atomic": bool }
+// filter takes the GET /api/tickets query fields; changes the PATCH fields
+// except title/description. Valid tickets are written in one transaction;
+// results are reported per ticket.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) Bulk() http.HandlerFunc {
+	type filterDTO struct {
+		Q          string `json:"q"`
+		Status     string `json:"status"`
+		Priority   string `json:"priority"`
+		Category   string `json:"category"`
+		Assignee   string `json:"assignee"`
+		TeamID     string `json:"team"`
+		SLA        string `json:"sla"`
+		Unassigned bool   `json:"unassigned"`
 	}
+	type changesDTO struct {
+		Category   *string `json:"category"`
+		Priority   *string `json:"priority"`
+		Status     *string `json:"status"`
+		Assignee   *string `json:"assignee"`
+		Department *string `json:"department"`
+		TeamID     *string `json:"teamId"`
+	}
+	type inDTO struct {
+		IDs     []string   `json:"ids"`
+		Filter  *filterDTO `json:"filter"`
+		Changes changesDTO `json:"changes"`
+		Atomic  bool       `json:"atomic"`
+	}
+	type resultDTO struct {
+		ID     string `json:"id"`
+		OK     bool   `json:"ok"`
+		Status int    `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in inDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+
+		req := service.BulkRequest{
+			IDs:    in.IDs,
+			Atomic: in.Atomic,
+			Changes: service.TicketPatch{
+				Category:   in.Changes.Category,
+				Priority:   in.Changes.Priority,
+				Status:     in.Changes.Status,
+				Assignee:   in.Changes.Assignee,
+				Department: in.Changes.Department,
+				TeamID:     in.Changes.TeamID,
+			},
+		}
+		if in.Filter != nil {
+			req.Filter = &repository.TicketFilter{
+				Q:          strings.TrimSpace(in.Filter.Q),
+				Status:     strings.TrimSpace(in.Filter.Status),
+				Priority:   strings.TrimSpace(in.Filter.Priority),
+				Category:   strings.TrimSpace(in.Filter.Category),
+				Assignee:   strings.TrimSpace(in.Filter.Assignee),
+				TeamID:     strings.TrimSpace(in.Filter.TeamID),
+				SLA:        strings.TrimSpace(in.Filter.SLA),
+				Unassigned: in.Filter.Unassigned,
+				OpenOnly:   in.Filter.Unassigned,
+			}
+		}
 
-	if h.users == nil {
-		return nil
+		results, err := h.svc.Bulk(r.Context(), actorFrom(r), req)
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+
+		items := make([]resultDTO, 0, len(results))
+		failed := 0
+		for _, res := range results {
+			item := resultDTO{ID: res.ID, OK: res.Err == nil, Status: http.StatusOK}
+			if res.Err != nil {
+				item.Status, item.Error = ticketErrorStatus(res.Err)
+				failed++
+			}
+			items = append(items, item)
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{
+			"items":     items,
+			"total":     len(items),
+			"succeeded": len(items) - failed,
+			"failed":    failed,
+		})
 	}
+}
 
-	u, err := h.users.GetByID(ctx, assignee)
-	if err != nil {
-		return err
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/merge
+// Body: { "targetId": "<uuid>" }. Folds ticket {id} into the target and
+// returns the target.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) Merge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			TargetID string `json:"targetId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil || strings.TrimSpace(in.TargetID) == "" {
+			utils.Error(w, http.StatusBadRequest, "targetId is required")
+			return
+		}
+		t, err := h.svc.Merge(r.Context(), actorFrom(r), chi.URLParam(r, "id"), strings.TrimSpace(in.TargetID))
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.Header().Set("ETag", ticketETag(t))
+		utils.JSON(w, http.StatusOK, t)
 	}
-	if u == nil || !u.Active {
-		return errors.New("assignee not found or inactive")
+}
+
+// -----------------------------------------------------------------------------
+// POST /api/tickets/{id}/links
+// Body: { "type": "<link type>", "ticketId": "<uuid>" } where type is one of
+// parent_of, child_of, blocks, blocked_by, relates_to, duplicate_of.
+// Returns the ticket's links.
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) AddLink() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			Type     string `json:"type"`
+			TicketID string `json:"ticketId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		links, err := h.svc.AddLink(r.Context(), actorFrom(r), chi.URLParam(r, "id"), strings.TrimSpace(in.TicketID), in.Type)
+		if err != nil {
+			ticketError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, map[string]any{"items": links, "total": len(links)})
 	}
-	if _, ok := allowedAssigneeRoles[strings.ToLower(u.Role)]; !ok {
-		return errors.New("assignee role not permitted")
+}
+
+// -----------------------------------------------------------------------------
+// DELETE /api/tickets/{id}/links/{linkId}
+// -----------------------------------------------------------------------------
+func (h *TicketHTTP) RemoveLink() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.RemoveLink(r.Context(), actorFrom(r), chi.URLParam(r, "id"), chi.URLParam(r, "linkId")); err != nil {
+			ticketError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
 	}
-	return nil
 }
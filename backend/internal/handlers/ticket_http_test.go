@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/middleware"
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+	"gh-ts/internal/service"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+		ok      bool
+	}{
+		{"", 0, true},
+		{"*", 0, true},
+		{`W/"7"`, 7, true},
+		{`"7"`, 7, true},
+		{" W/\"12\" ", 12, true},
+		{`W/"0"`, 0, false},
+		{`W/"-1"`, 0, false},
+		{`W/"abc"`, 0, false},
+		{`W/"1", W/"2"`, 0, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPatch, "/", nil)
+		if tt.header != "" {
+			r.Header.Set("If-Match", tt.header)
+		}
+		version, ok := ifMatchVersion(r)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("ifMatchVersion(%q) = %d, %v; want %d, %v", tt.header, version, ok, tt.version, tt.ok)
+		}
+	}
+}
+
+// versionedTickets holds one ticket and rejects updates based on a stale
+// version, like the Postgres repository.
+type versionedTickets struct {
+	repository.TicketRepository
+	t models.Ticket
+}
+
+func (f *versionedTickets) Get(_ context.Context, id string) (*models.Ticket, error) {
+	if id != f.t.ID {
+		return nil, nil
+	}
+	cp := f.t
+	return &cp, nil
+}
+
+func (f *versionedTickets) Update(_ context.Context, t *models.Ticket, _ []models.TicketEvent) error {
+	if t.Version != f.t.Version {
+		return repository.ErrVersionConflict
+	}
+	t.Version++
+	f.t = *t
+	return nil
+}
+
+func TestUpdateIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		status  int
+		etag    string
+	}{
+		{"without If-Match", "", http.StatusOK, `W/"3"`},
+		{"current ETag", `W/"2"`, http.StatusOK, `W/"3"`},
+		{"stale ETag", `W/"1"`, http.StatusPreconditionFailed, ""},
+		{"unparsable ETag", `"v2"`, http.StatusPreconditionFailed, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := &versionedTickets{t: models.Ticket{ID: "t1", Title: "Printer jammed", Status: "Open", Version: 2}}
+			h := NewTicketHTTP(tickets, service.NewTicketService(service.TicketDeps{Tickets: tickets}))
+
+			r := httptest.NewRequest(http.MethodPatch, "/api/tickets/t1", strings.NewReader(`{"title":"Printer on fire"}`))
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "t1")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, middleware.CtxUserID, "a1")
+			ctx = context.WithValue(ctx, middleware.CtxRole, "agent")
+			w := httptest.NewRecorder()
+			h.Update()(w, r.WithContext(ctx))
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.etag {
+				t.Fatalf("ETag = %q, want %q", got, tt.etag)
+			}
+			wantTitle := "Printer jammed"
+			if tt.status == http.StatusOK {
+				wantTitle = "Printer on fire"
+			}
+			if tickets.t.Title != wantTitle {
+				t.Fatalf("stored title = %q, want %q", tickets.t.Title, wantTitle)
+			}
+		})
+	}
+}
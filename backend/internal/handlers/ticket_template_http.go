@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/middleware"
+	"gh-ts/internal/models"
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type TicketTemplateHTTP struct {
+	svc *service.TicketTemplateService
+}
+
+func NewTicketTemplateHTTP(svc *service.TicketTemplateService) *TicketTemplateHTTP {
+	return &TicketTemplateHTTP{svc: svc}
+}
+
+type ticketTemplateDTO struct {
+	Name           string   `json:"name"`
+	Category       string   `json:"category"`
+	TitlePattern   string   `json:"titlePattern"`
+	Description    string   `json:"description"`
+	Priority       string   `json:"priority"`
+	RequiredFields []string `json:"requiredFields"`
+	Position       int      `json:"position"`
+	Active         *bool    `json:"active"`
+}
+
+func (d ticketTemplateDTO) toModel() *models.TicketTemplate {
+	return &models.TicketTemplate{
+		Name:           d.Name,
+		Category:       d.Category,
+		TitlePattern:   d.TitlePattern,
+		Description:    d.Description,
+		Priority:       d.Priority,
+		RequiredFields: d.RequiredFields,
+		Position:       d.Position,
+		Active:         activeOrDefault(d.Active),
+	}
+}
+
+// ticketTemplateError maps ticket template service errors to HTTP statuses.
+func ticketTemplateError(w http.ResponseWriter, err error) {
+	var ve *service.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		utils.Error(w, http.StatusBadRequest, ve.Msg)
+	case errors.Is(err, service.ErrTicketTemplateNotFound):
+		utils.Error(w, http.StatusNotFound, "not found")
+	default:
+		utils.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GET /api/ticket-templates?category=&active=true
+// Only admins see inactive templates.
+func (h *TicketTemplateHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		activeOnly, _ := strconv.ParseBool(q.Get("active"))
+		if role, _ := utils.GetString(r.Context(), middleware.CtxRole); role != "admin" {
+			activeOnly = true
+		}
+		items, err := h.svc.List(r.Context(), q.Get("category"), activeOnly)
+		if err != nil {
+			ticketTemplateError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// GET /api/ticket-templates/{id}
+func (h *TicketTemplateHTTP) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.svc.Get(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			ticketTemplateError(w, err)
+			return
+		}
+		if role, _ := utils.GetString(r.Context(), middleware.CtxRole); role != "admin" && !t.Active {
+			utils.Error(w, http.StatusNotFound, "not found")
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// POST /api/ticket-templates
+func (h *TicketTemplateHTTP) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in ticketTemplateDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := in.toModel()
+		if err := h.svc.Create(r.Context(), t); err != nil {
+			ticketTemplateError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusCreated, t)
+	}
+}
+
+// PUT /api/ticket-templates/{id}
+func (h *TicketTemplateHTTP) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in ticketTemplateDTO
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		t := in.toModel()
+		t.ID = chi.URLParam(r, "id")
+		if err := h.svc.Update(r.Context(), t); err != nil {
+			ticketTemplateError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, t)
+	}
+}
+
+// DELETE /api/ticket-templates/{id}
+func (h *TicketTemplateHTTP) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			ticketTemplateError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
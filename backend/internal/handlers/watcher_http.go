@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"gh-ts/internal/service"
+	"gh-ts/internal/utils"
+)
+
+type WatcherHTTP struct {
+	svc *service.WatcherService
+}
+
+func NewWatcherHTTP(svc *service.WatcherService) *WatcherHTTP {
+	return &WatcherHTTP{svc: svc}
+}
+
+// watcherError maps watcher service errors to HTTP statuses.
+func watcherError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrWatcherNotFound) {
+		utils.Error(w, http.StatusNotFound, "not found")
+		return
+	}
+	ticketError(w, err)
+}
+
+// GET /api/tickets/{id}/watchers
+func (h *WatcherHTTP) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.List(r.Context(), actorFrom(r), chi.URLParam(r, "id"))
+		if err != nil {
+			watcherError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// POST /api/tickets/{id}/watch
+func (h *WatcherHTTP) Follow() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := h.svc.Follow(r.Context(), actorFrom(r), chi.URLParam(r, "id"))
+		if err != nil {
+			watcherError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// DELETE /api/tickets/{id}/watch
+func (h *WatcherHTTP) Unfollow() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Unfollow(r.Context(), actorFrom(r), chi.URLParam(r, "id")); err != nil {
+			watcherError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// POST /api/tickets/{id}/watchers  body: { userId }
+func (h *WatcherHTTP) Add() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			UserID string `json:"userId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			utils.Error(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+		items, err := h.svc.AddCC(r.Context(), actorFrom(r), chi.URLParam(r, "id"), in.UserID)
+		if err != nil {
+			watcherError(w, err)
+			return
+		}
+		utils.JSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+	}
+}
+
+// DELETE /api/tickets/{id}/watchers/{userId}
+func (h *WatcherHTTP) Remove() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.svc.Remove(r.Context(), actorFrom(r), chi.URLParam(r, "id"), chi.URLParam(r, "userId")); err != nil {
+			watcherError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AliasFormat configures aliases for tickets of one category or one team:
+// Prefix, then the year and a dash if IncludeYear, then the zero-padded
+// counter. Example: prefix "NET-" gives NET-2025-00001.
+type AliasFormat struct {
+	ID          string    `json:"id"`
+	Prefix      string    `json:"prefix"`
+	Category    string    `json:"category,omitempty"`
+	TeamID      string    `json:"teamId,omitempty"`
+	IncludeYear bool      `json:"includeYear"`
+	YearlyReset bool      `json:"yearlyReset"`
+	Padding     int       `json:"padding"`
+	Counter     int64     `json:"counter"` // last number issued
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
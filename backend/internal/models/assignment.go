@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Assignment strategies.
+const (
+	AssignRoundRobin  = "round_robin"  // rotate across active agents
+	AssignLeastLoaded = "least_loaded" // agent with fewest open tickets
+	AssignUser        = "user"         // fixed target user
+	AssignSkills      = "skills"       // best skill match for the category
+)
+
+// AssignmentRule routes new tickets matching Category/Department (empty =
+// any) to an assignee chosen by Strategy. Rules are tried by Position.
+type AssignmentRule struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Position     int       `json:"position"`
+	Category     string    `json:"category,omitempty"`
+	Department   string    `json:"department,omitempty"`
+	Strategy     string    `json:"strategy"`
+	TargetUserID string    `json:"targetUserId,omitempty"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
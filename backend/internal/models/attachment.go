@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type Attachment struct {
+	ID          string    `json:"id"`
+	TicketID    string    `json:"ticketId"`
+	CommentID   string    `json:"commentId,omitempty"` // empty = attached to the ticket itself
+	UploadedBy  string    `json:"uploadedBy,omitempty"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// Set on reads: whether the owning comment is an internal note.
+	Internal bool `json:"internal,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BusinessCalendar defines working hours (per weekday key mon..sun), a
+// timezone and holidays used for SLA deadline math.
+type BusinessCalendar struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Timezone   string                 `json:"timezone"`
+	Hours      map[string][]TimeRange `json:"hours"`
+	Department string                 `json:"department,omitempty"` // empty = not department-specific
+	IsDefault  bool                   `json:"isDefault"`
+	Holidays   []Holiday              `json:"holidays"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	UpdatedAt  time.Time              `json:"updatedAt"`
+}
+
+// TimeRange is a local "HH:MM"–"HH:MM" working window.
+type TimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type Holiday struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Custom field types.
+const (
+	FieldText        = "text"
+	FieldNumber      = "number"
+	FieldDate        = "date" // YYYY-MM-DD
+	FieldSelect      = "select"
+	FieldMultiSelect = "multi_select"
+	FieldUser        = "user" // user id
+)
+
+// CustomField is an admin-defined ticket field. Values are stored on the
+// ticket under Key. Categories limits the field to those ticket categories
+// (empty = all categories).
+type CustomField struct {
+	ID         string    `json:"id"`
+	Key        string    `json:"key"`
+	Label      string    `json:"label"`
+	Type       string    `json:"type"`
+	Options    []string  `json:"options"`
+	Required   bool      `json:"required"`
+	Categories []string  `json:"categories"`
+	Position   int       `json:"position"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// AppliesTo reports whether the field is used for tickets of category.
+func (f *CustomField) AppliesTo(category string) bool {
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range f.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
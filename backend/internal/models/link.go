@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Stored link kinds (see migration 022 for direction).
+const (
+	LinkParent    = "parent"
+	LinkBlocks    = "blocks"
+	LinkRelates   = "relates"
+	LinkDuplicate = "duplicate"
+)
+
+// TicketLink is a link as seen from one ticket. Type is relative to that
+// ticket: parent_of, child_of, blocks, blocked_by, relates_to, duplicate_of
+// or duplicated_by; TicketID/Alias/Title/Status describe the other ticket.
+type TicketLink struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	TicketID  string    `json:"ticketId"`
+	Alias     string    `json:"alias"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// OtherCreatedBy is the creator of the other ticket (visibility checks).
+	OtherCreatedBy string `json:"-"`
+}
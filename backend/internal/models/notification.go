@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Notification types; users can turn each off in their preferences.
+const (
+	NotifyAssigned      = "assigned"       // a watched ticket was assigned (or assigned to the user)
+	NotifyCommented     = "commented"      // a comment on a watched ticket
+	NotifyStatusChanged = "status_changed" // a watched ticket changed status
+	NotifyMentioned     = "mentioned"      // the user was @-mentioned in a comment
+)
+
+// NotificationTypes lists all notification types in display order.
+var NotificationTypes = []string{NotifyAssigned, NotifyCommented, NotifyStatusChanged, NotifyMentioned}
+
+// Notification is an in-app message for one user about a ticket.
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"userId"`
+	Type      string     `json:"type"`
+	TicketID  string     `json:"ticketId,omitempty"`
+	ActorID   string     `json:"actorId,omitempty"` // empty = system
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	// Populated when joining with tickets and users.
+	TicketAlias string `json:"ticketAlias,omitempty"`
+	TicketTitle string `json:"ticketTitle,omitempty"`
+	ActorName   string `json:"actorName,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// SLAPolicy sets response/resolution targets for tickets of a priority,
+// optionally narrowed to a category and/or department.
+type SLAPolicy struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	Priority             string    `json:"priority"`
+	Category             string    `json:"category,omitempty"`
+	Department           string    `json:"department,omitempty"`
+	FirstResponseMinutes int       `json:"firstResponseMinutes"`
+	ResolutionMinutes    int       `json:"resolutionMinutes"`
+	Active               bool      `json:"active"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+// SLA escalation levels applied by the background worker.
+const (
+	SLALevelNone     = 0
+	SLALevelAtRisk   = 1
+	SLALevelBreached = 2
+)
+
+// TicketSLA is the SLA state of a ticket. Breach/at-risk flags are computed
+// on read and are not persisted.
+type TicketSLA struct {
+	PolicyID         string     `json:"policyId,omitempty"`
+	FirstResponseDue *time.Time `json:"firstResponseDue"`
+	ResolutionDue    *time.Time `json:"resolutionDue"`
+	FirstRespondedAt *time.Time `json:"firstRespondedAt"`
+	PausedAt         *time.Time `json:"pausedAt,omitempty"` // set while Pending
+	PausedSeconds    int64      `json:"-"`                  // business time spent paused
+	EscalationLevel  int        `json:"escalationLevel"`    // see SLALevel* constants
+
+	FirstResponseBreached bool `json:"firstResponseBreached"`
+	ResolutionBreached    bool `json:"resolutionBreached"`
+	AtRisk                bool `json:"atRisk"`
+}
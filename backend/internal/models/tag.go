@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Tag is a free-form ticket label.
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color,omitempty"` // e.g. #1f6feb
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TagCount is the per-tag ticket count reported by /api/reports/tags.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Open  int    `json:"open"`
+	Total int    `json:"total"`
+}
@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+// System status names the lifecycle relies on.
+const (
+	StatusNew      = "New"
+	StatusPending  = "Pending"
+	StatusResolved = "Resolved"
+	StatusClosed   = "Closed"
+)
+
+// TicketStatus is an admin-managed ticket status. System statuses (New,
+// Pending, Resolved, Closed) drive the lifecycle and keep their names.
+type TicketStatus struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Color     string    `json:"color,omitempty"`
+	Terminal  bool      `json:"terminal"` // ends the lifecycle (not open)
+	System    bool      `json:"system"`
+	Next      []string  `json:"next"` // statuses this one may move to
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TicketPriority is an admin-managed priority; Position orders priorities
+// from lowest to highest.
+type TicketPriority struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Color     string    `json:"color,omitempty"`
+	Default   bool      `json:"default"` // used when a ticket names none
+	High      bool      `json:"high"`    // counted as high priority in reports
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TicketCategory is an admin-managed ticket category.
+type TicketCategory struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Color     string    `json:"color,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Taxonomy is the full configuration, each list in position order.
+type Taxonomy struct {
+	Statuses   []TicketStatus   `json:"statuses"`
+	Priorities []TicketPriority `json:"priorities"`
+	Categories []TicketCategory `json:"categories"`
+}
+
+// Status returns the status named name, or nil.
+func (t *Taxonomy) Status(name string) *TicketStatus {
+	for i := range t.Statuses {
+		if t.Statuses[i].Name == name {
+			return &t.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// Priority returns the priority named name, or nil.
+func (t *Taxonomy) Priority(name string) *TicketPriority {
+	for i := range t.Priorities {
+		if t.Priorities[i].Name == name {
+			return &t.Priorities[i]
+		}
+	}
+	return nil
+}
+
+// Category returns the category named name, or nil.
+func (t *Taxonomy) Category(name string) *TicketCategory {
+	for i := range t.Categories {
+		if t.Categories[i].Name == name {
+			return &t.Categories[i]
+		}
+	}
+	return nil
+}
+
+// IsTerminal reports whether status ends the ticket lifecycle.
+func (t *Taxonomy) IsTerminal(status string) bool {
+	s := t.Status(status)
+	return s != nil && s.Terminal
+}
+
+// TerminalStatuses returns the names of terminal statuses.
+func (t *Taxonomy) TerminalStatuses() []string {
+	var out []string
+	for _, s := range t.Statuses {
+		if s.Terminal {
+			out = append(out, s.Name)
+		}
+	}
+	return out
+}
+
+// HighPriorities returns the names of priorities flagged as high.
+func (t *Taxonomy) HighPriorities() []string {
+	var out []string
+	for _, p := range t.Priorities {
+		if p.High {
+			out = append(out, p.Name)
+		}
+	}
+	return out
+}
+
+// DefaultPriority returns the default priority, else the lowest active one.
+func (t *Taxonomy) DefaultPriority() string {
+	for _, p := range t.Priorities {
+		if p.Default {
+			return p.Name
+		}
+	}
+	for _, p := range t.Priorities {
+		if p.Active {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// NextPriority returns the next higher active priority after name, or ""
+// if name is the highest.
+func (t *Taxonomy) NextPriority(name string) string {
+	cur := t.Priority(name)
+	if cur == nil {
+		return ""
+	}
+	for _, p := range t.Priorities {
+		if p.Active && p.Position > cur.Position {
+			return p.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Team is a support group with its own ticket queue.
+type Team struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Members     []TeamMember `json:"members,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// TeamMember is a user in a team. Leads (typically supervisors) manage the
+// team's queue.
+type TeamMember struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Active bool   `json:"active"`
+	Lead   bool   `json:"lead"`
+}
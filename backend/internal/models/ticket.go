@@ -3,29 +3,97 @@ package models
 import "time"
 
 type Ticket struct {
-	ID          string    `json:"id"`
-	Alias       string    `json:"alias"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	Priority    string    `json:"priority"`
-	Status      string    `json:"status"`
-	Assignee    string    `json:"assignee"`
-	Department  string    `json:"department"`
-	CreatedBy   string    `json:"createdBy"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	Comments    []Comment `json:"comments,omitempty"`
+	ID          string       `json:"id"`
+	Alias       string       `json:"alias"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Category    string       `json:"category"`
+	Priority    string       `json:"priority"`
+	Status      string       `json:"status"`
+	Assignee    string       `json:"assignee"`
+	Department  string       `json:"department"`
+	TeamID      string       `json:"teamId,omitempty"` // team queue, if any
+	CreatedBy   string       `json:"createdBy"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	Version     int          `json:"version"` // bumped on every write; see ETag
+	Comments    []Comment    `json:"comments,omitempty"`
+	Links       []TicketLink `json:"links,omitempty"`
+	Tags        []string     `json:"tags"`
+
+	// CustomFields holds values of admin-defined fields by key (see CustomField).
+	CustomFields map[string]any `json:"customFields"`
+
+	// Lifecycle stamps set by status transitions (nil until reached).
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty"`
+
+	// MergedInto is the ticket this one was merged into as a duplicate.
+	MergedInto string `json:"mergedInto,omitempty"`
+
+	SLA TicketSLA `json:"sla"`
 
 	// --- Optional display fields ---
 	// Populated automatically when joining with users table.
 	AssigneeName  string `json:"assigneeName,omitempty"`
 	AssigneeEmail string `json:"assigneeEmail,omitempty"`
+	TeamName      string `json:"teamName,omitempty"`
 }
 
 type Comment struct {
+	ID        string     `json:"id"`
+	TicketID  string     `json:"ticketId"`
+	AuthorID  string     `json:"authorId,omitempty"`
+	Text      string     `json:"text"`
+	Internal  bool       `json:"internal"` // staff-only note
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+
+	// Populated when joining with users table.
+	AuthorName  string `json:"authorName,omitempty"`
+	AuthorEmail string `json:"authorEmail,omitempty"`
+
+	Mentions []Mention `json:"mentions"`
+}
+
+// Mention is a user @-mentioned in a comment. Handle is the text after '@'
+// as written (a name without spaces or an email).
+type Mention struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Handle string `json:"handle"`
+}
+
+// CommentRevision holds the text a comment had before an edit.
+type CommentRevision struct {
 	ID        string    `json:"id"`
-	TicketID  string    `json:"ticketId"`
+	CommentID string    `json:"commentId"`
 	Text      string    `json:"text"`
+	EditedBy  string    `json:"editedBy,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+// Ticket event types.
+const (
+	TicketEventChange         = "change"          // a single field changed
+	TicketEventCommentDeleted = "comment_deleted" // old_value holds the comment id
+	TicketEventSLAEscalation  = "sla_escalation"  // field = at_risk|breached, new_value = actions taken
+	TicketEventMerge          = "merge"           // field = merged_into|merged_from, new_value = other ticket id
+)
+
+// TicketEvent is one entry in a ticket's change history.
+type TicketEvent struct {
+	ID        string    `json:"id"`
+	TicketID  string    `json:"ticketId"`
+	ActorID   string    `json:"actorId,omitempty"` // empty = system
+	Type      string    `json:"type"`              // see TicketEvent* constants
+	Field     string    `json:"field,omitempty"`
+	OldValue  string    `json:"oldValue"`
+	NewValue  string    `json:"newValue"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Populated when joining with users table.
+	ActorName  string `json:"actorName,omitempty"`
+	ActorEmail string `json:"actorEmail,omitempty"`
+}
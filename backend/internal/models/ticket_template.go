@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// TicketTemplate is a request form for one category. Tickets created from
+// it get its category, default priority and description skeleton; the
+// title is rendered from TitlePattern ({{key}} = custom field value) when
+// the requester gives none.
+type TicketTemplate struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Category       string    `json:"category"`
+	TitlePattern   string    `json:"titlePattern"`
+	Description    string    `json:"description"`
+	Priority       string    `json:"priority,omitempty"` // default priority
+	RequiredFields []string  `json:"requiredFields"`     // custom field keys
+	Position       int       `json:"position"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
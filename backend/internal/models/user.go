@@ -11,3 +11,12 @@ type User struct {
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// UserSkill is a skill tag on an agent with proficiency 1 (basic) to 5
+// (expert). Skills are matched against ticket categories when routing.
+type UserSkill struct {
+	UserID      string    `json:"userId"`
+	Skill       string    `json:"skill"`
+	Proficiency int       `json:"proficiency"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Watcher sources: why a user follows a ticket.
+const (
+	WatchCreator  = "creator"
+	WatchAssignee = "assignee"
+	WatchFollow   = "follow" // the user followed the ticket
+	WatchCC       = "cc"     // staff added the user
+	WatchMention  = "mention"
+)
+
+// Watcher is a user following a ticket; watchers are notified of changes.
+type Watcher struct {
+	TicketID  string    `json:"ticketId"`
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Source    string    `json:"source"`
+	AddedBy   string    `json:"addedBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
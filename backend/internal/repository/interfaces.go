@@ -3,23 +3,82 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gh-ts/internal/models"
 )
 
-// Sentinel error used when no active admin is available in DB.
-var ErrNoActiveAdmin = errors.New("no active admin found")
+// Sentinel errors used when no active admin/user is available in DB.
+var (
+	ErrNoActiveAdmin = errors.New("no active admin found")
+	ErrNoActiveUser  = errors.New("no active user found")
+	// ErrVersionConflict is returned when a row changed since it was read.
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrInUse is returned when deleting a value that is still referenced.
+	ErrInUse = errors.New("in use")
+	// ErrNotFound is returned when the row to update or delete does not exist.
+	ErrNotFound = errors.New("not found")
+)
 
 type TicketRepository interface {
 	List(ctx context.Context, q string, status string, limit, offset int) ([]models.Ticket, error)
 	Get(ctx context.Context, id string) (*models.Ticket, error)
+	// ResolveAlias returns the id of the ticket with alias (case-insensitive),
+	// or "" if there is none.
+	ResolveAlias(ctx context.Context, alias string) (string, error)
 	Create(ctx context.Context, t *models.Ticket) error
-	Update(ctx context.Context, t *models.Ticket) error
-	AddComment(ctx context.Context, ticketID string, text string) (*models.Comment, error)
+	// Update persists t and appends events to its history in one transaction.
+	// It only applies if the stored version still equals t.Version, else it
+	// returns ErrVersionConflict; on success t.Version is the new version.
+	Update(ctx context.Context, t *models.Ticket, events []models.TicketEvent) error
+	// UpdateBatch applies several updates (same rules as Update) atomically.
+	UpdateBatch(ctx context.Context, updates []TicketUpdate) error
+	History(ctx context.Context, ticketID string) ([]models.TicketEvent, error)
+	// MarkFirstResponse stamps the SLA first response time if not yet set.
+	MarkFirstResponse(ctx context.Context, ticketID string, at time.Time) error
+	// ClaimTicket atomically assigns an open ticket to userID if it has no
+	// assignee and is either in no team queue or in one of userID's teams.
+	// It reports false if the ticket was not claimable.
+	ClaimTicket(ctx context.Context, ticketID, userID string) (bool, error)
+	// ReleaseTicket clears the assignee of ticketID. With a non-empty
+	// assignee it only succeeds if that user currently holds the ticket.
+	ReleaseTicket(ctx context.Context, ticketID, assignee, actorID string) (bool, error)
+	// MergeTicket closes sourceID as merged into targetID and moves its
+	// comments and attachments there, recording both sides in history.
+	// It returns ErrVersionConflict if the source was merged meanwhile.
+	MergeTicket(ctx context.Context, sourceID, targetID, actorID string) error
+	// ListSLAEscalations returns tickets due for SLA escalation.
+	ListSLAEscalations(ctx context.Context, limit int) ([]models.Ticket, error)
+	AddComment(ctx context.Context, ticketID, authorID, text string, internal bool) (*models.Comment, error)
+	// GetComment returns nil if the comment does not exist on ticketID or is deleted.
+	GetComment(ctx context.Context, ticketID, commentID string) (*models.Comment, error)
+	// UpdateComment stores the previous text as a revision and sets the new text.
+	UpdateComment(ctx context.Context, commentID, editorID, text string) (*models.Comment, error)
+	// DeleteComment soft-deletes a comment and records it in the ticket history.
+	DeleteComment(ctx context.Context, commentID, actorID string) error
+	// SetCommentMentions replaces the mentions stored for a comment.
+	SetCommentMentions(ctx context.Context, commentID string, mentions []models.Mention) error
+	CommentRevisions(ctx context.Context, commentID string) ([]models.CommentRevision, error)
+
+	// Advanced filtered listing (see TicketFilter)
+	ListAdv(ctx context.Context, f TicketFilter) ([]models.Ticket, error)
+	CountAdv(ctx context.Context, f TicketFilter) (int, error)
+}
+
+// TicketUpdate is one element of TicketRepository.UpdateBatch.
+type TicketUpdate struct {
+	Ticket *models.Ticket
+	Events []models.TicketEvent
+}
 
-	// Optional advanced methods (if implemented by your concrete repo)
-	// ListAdv(ctx context.Context, q, status, priority, category, assignee, sort, order string, limit, offset int) ([]models.Ticket, error)
-	// CountAdv(ctx context.Context, q, status, priority, category, assignee string) (int, error)
+// TicketReportRepository provides the counters behind the summary report.
+type TicketReportRepository interface {
+	// CountByStatus counts tickets whose status is (inclusive) or is not in statuses.
+	CountByStatus(ctx context.Context, statuses []string, inclusive bool) (int, error)
+	// CountResolvedSince counts tickets in a terminal status resolved since the given time.
+	CountResolvedSince(ctx context.Context, since time.Time) (int, error)
+	// CountOpenByPriorities counts open tickets with one of the given priorities.
+	CountOpenByPriorities(ctx context.Context, prios []string) (int, error)
 }
 
 type UserRepository interface {
@@ -36,4 +95,212 @@ type UserRepository interface {
 	// Used for auto-assignment: return first active admin id.
 	// If none is present, MUST return ErrNoActiveAdmin.
 	FirstActiveAdminID(ctx context.Context) (string, error)
+	// FirstActiveIDByRole returns the longest-standing active user with role.
+	// If none is present, MUST return ErrNoActiveUser.
+	FirstActiveIDByRole(ctx context.Context, role string) (string, error)
+	// FindByMention returns active users matching an @-mention handle: the
+	// email if handle contains '@', else the name with whitespace removed
+	// (both case-insensitive).
+	FindByMention(ctx context.Context, handle string) ([]models.User, error)
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *models.Attachment) error
+	// Get returns nil if the attachment does not exist on ticketID.
+	Get(ctx context.Context, ticketID, id string) (*models.Attachment, error)
+	// ListByTicket returns attachments of a ticket and of its non-deleted comments.
+	ListByTicket(ctx context.Context, ticketID string) ([]models.Attachment, error)
+	// KeyExists reports whether a storage key is referenced by any attachment.
+	KeyExists(ctx context.Context, key string) (bool, error)
+}
+
+type SLAPolicyRepository interface {
+	List(ctx context.Context) ([]models.SLAPolicy, error)
+	Get(ctx context.Context, id string) (*models.SLAPolicy, error)
+	// GetByScope returns the policy for exactly this priority, category and
+	// department ("" meaning any), or nil if there is none.
+	GetByScope(ctx context.Context, priority, category, department string) (*models.SLAPolicy, error)
+	Create(ctx context.Context, p *models.SLAPolicy) error
+	Update(ctx context.Context, p *models.SLAPolicy) error
+	Delete(ctx context.Context, id string) error
+	// Match returns the most specific active policy for the given ticket
+	// attributes, or nil if none applies.
+	Match(ctx context.Context, priority, category, department string) (*models.SLAPolicy, error)
+}
+
+type CalendarRepository interface {
+	List(ctx context.Context) ([]models.BusinessCalendar, error)
+	Get(ctx context.Context, id string) (*models.BusinessCalendar, error)
+	// Create/Update write the calendar together with its holidays.
+	Create(ctx context.Context, c *models.BusinessCalendar) error
+	Update(ctx context.Context, c *models.BusinessCalendar) error
+	Delete(ctx context.Context, id string) error
+	// ForDepartment returns the calendar bound to department, else the
+	// default calendar, else nil.
+	ForDepartment(ctx context.Context, department string) (*models.BusinessCalendar, error)
+}
+
+type AssignmentRepository interface {
+	ListRules(ctx context.Context, activeOnly bool) ([]models.AssignmentRule, error)
+	GetRule(ctx context.Context, id string) (*models.AssignmentRule, error)
+	CreateRule(ctx context.Context, r *models.AssignmentRule) error
+	UpdateRule(ctx context.Context, r *models.AssignmentRule) error
+	DeleteRule(ctx context.Context, id string) error
+
+	// NextRoundRobin atomically advances the rule's cursor to the next active
+	// agent and returns it ("" if there are no active agents).
+	NextRoundRobin(ctx context.Context, ruleID string) (string, error)
+	// LeastLoadedAgent returns the active agent with the fewest open tickets
+	// ("" if there are no active agents).
+	LeastLoadedAgent(ctx context.Context) (string, error)
+	// BestSkilledAgent returns the active agent with the highest proficiency
+	// in skill, ties broken by fewest open tickets ("" if nobody has it).
+	BestSkilledAgent(ctx context.Context, skill string) (string, error)
+}
+
+type SkillRepository interface {
+	List(ctx context.Context, userID string) ([]models.UserSkill, error)
+	// Upsert sets the proficiency of a user's skill, adding it if missing.
+	Upsert(ctx context.Context, s *models.UserSkill) error
+	// Delete returns ErrNotFound if the user does not have the skill.
+	Delete(ctx context.Context, userID, skill string) error
+}
+
+type TeamRepository interface {
+	List(ctx context.Context) ([]models.Team, error)
+	// Get returns the team with its members, or nil if it does not exist.
+	Get(ctx context.Context, id string) (*models.Team, error)
+	GetByName(ctx context.Context, name string) (*models.Team, error)
+	Create(ctx context.Context, t *models.Team) error
+	Update(ctx context.Context, t *models.Team) error
+	// Delete returns ErrNotFound if the team does not exist.
+	Delete(ctx context.Context, id string) error
+
+	// SetMember adds userID to the team or updates its lead flag.
+	SetMember(ctx context.Context, teamID, userID string, lead bool) error
+	// RemoveMember returns ErrNotFound if userID is not in the team.
+	RemoveMember(ctx context.Context, teamID, userID string) error
+	// Membership reports whether userID is in the team and whether it leads it.
+	Membership(ctx context.Context, teamID, userID string) (member, lead bool, err error)
+	// OpenLoad counts open tickets in the team queue per assignee.
+	OpenLoad(ctx context.Context, teamID string) (map[string]int, error)
+}
+
+type TicketLinkRepository interface {
+	// List returns ticketID's links, oriented from ticketID.
+	List(ctx context.Context, ticketID string) ([]models.TicketLink, error)
+	// Create stores a link of kind from sourceID to targetID and returns its id.
+	Create(ctx context.Context, sourceID, targetID, kind, createdBy string) (string, error)
+	// Delete removes a link that involves ticketID; ErrNotFound if none.
+	Delete(ctx context.Context, ticketID, linkID string) error
+	// Exists reports whether a link of kind between the two tickets exists.
+	Exists(ctx context.Context, sourceID, targetID, kind string) (bool, error)
+	// HasParent reports whether ticketID already has a parent.
+	HasParent(ctx context.Context, ticketID string) (bool, error)
+	// IsAncestor reports whether ancestorID is a parent, grandparent, ... of ticketID.
+	IsAncestor(ctx context.Context, ancestorID, ticketID string) (bool, error)
+	// OpenChildren counts child tickets that are not in a terminal status.
+	OpenChildren(ctx context.Context, ticketID string) (int, error)
+}
+
+type AliasFormatRepository interface {
+	List(ctx context.Context) ([]models.AliasFormat, error)
+	Get(ctx context.Context, id string) (*models.AliasFormat, error)
+	// FindConflict returns the id of another format using prefix, category or
+	// team (excluding id), or "".
+	FindConflict(ctx context.Context, f *models.AliasFormat) (string, error)
+	Create(ctx context.Context, f *models.AliasFormat) error
+	Update(ctx context.Context, f *models.AliasFormat) error
+	Delete(ctx context.Context, id string) error
+}
+
+type TagRepository interface {
+	List(ctx context.Context) ([]models.Tag, error)
+	Get(ctx context.Context, id string) (*models.Tag, error)
+	// GetByName returns the tag named name (case-insensitive), or nil.
+	GetByName(ctx context.Context, name string) (*models.Tag, error)
+	Create(ctx context.Context, t *models.Tag) error
+	Update(ctx context.Context, t *models.Tag) error
+	// Delete removes the tag from all tickets; ErrNotFound if none.
+	Delete(ctx context.Context, id string) error
+
+	// Attach tags ticketID with tag and records it in the ticket history.
+	// It reports false if the ticket already had the tag.
+	Attach(ctx context.Context, ticketID string, tag *models.Tag, actorID string) (bool, error)
+	// Detach is the reverse of Attach.
+	Detach(ctx context.Context, ticketID string, tag *models.Tag, actorID string) (bool, error)
+	// Counts returns open and total ticket counts per tag, most used first.
+	Counts(ctx context.Context) ([]models.TagCount, error)
+}
+
+// TaxonomyRepository stores ticket statuses, priorities and categories.
+// Updates that rename an entry also rename it on tickets and rules in the
+// same transaction. Deletes return ErrInUse while anything references the
+// entry. Updates and deletes return ErrNotFound if it does not exist.
+type TaxonomyRepository interface {
+	Load(ctx context.Context) (*models.Taxonomy, error)
+
+	CreateStatus(ctx context.Context, s *models.TicketStatus) error
+	UpdateStatus(ctx context.Context, s *models.TicketStatus) error
+	DeleteStatus(ctx context.Context, id string) error
+
+	// CreatePriority/UpdatePriority clear the default flag on other
+	// priorities when p is the default.
+	CreatePriority(ctx context.Context, p *models.TicketPriority) error
+	UpdatePriority(ctx context.Context, p *models.TicketPriority) error
+	DeletePriority(ctx context.Context, id string) error
+
+	CreateCategory(ctx context.Context, c *models.TicketCategory) error
+	UpdateCategory(ctx context.Context, c *models.TicketCategory) error
+	DeleteCategory(ctx context.Context, id string) error
+}
+
+type CustomFieldRepository interface {
+	// List returns fields in position order, optionally only active ones.
+	List(ctx context.Context, activeOnly bool) ([]models.CustomField, error)
+	Get(ctx context.Context, id string) (*models.CustomField, error)
+	Create(ctx context.Context, f *models.CustomField) error
+	Update(ctx context.Context, f *models.CustomField) error
+	// Delete removes the field and its values from all tickets; ErrNotFound
+	// if it does not exist.
+	Delete(ctx context.Context, id string) error
+}
+
+type TicketTemplateRepository interface {
+	// List returns templates in position order, optionally only active ones
+	// and/or those of one category.
+	List(ctx context.Context, category string, activeOnly bool) ([]models.TicketTemplate, error)
+	Get(ctx context.Context, id string) (*models.TicketTemplate, error)
+	Create(ctx context.Context, t *models.TicketTemplate) error
+	Update(ctx context.Context, t *models.TicketTemplate) error
+	// Delete returns ErrNotFound if the template does not exist.
+	Delete(ctx context.Context, id string) error
+}
+
+type WatcherRepository interface {
+	// List returns a ticket's watchers with user names, oldest first.
+	List(ctx context.Context, ticketID string) ([]models.Watcher, error)
+	// Users returns a ticket's active watchers.
+	Users(ctx context.Context, ticketID string) ([]models.User, error)
+	// Add makes userID watch ticketID; it reports false if already watching
+	// (the existing source is kept).
+	Add(ctx context.Context, ticketID, userID, source, addedBy string) (bool, error)
+	// Remove reports false if userID was not watching ticketID.
+	Remove(ctx context.Context, ticketID, userID string) (bool, error)
+}
+
+type NotificationRepository interface {
+	// Create stores a copy of n for each of userIDs, skipping users who
+	// turned n.Type off.
+	Create(ctx context.Context, n models.Notification, userIDs []string) error
+	// List returns a user's notifications, newest first, and the total.
+	List(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]models.Notification, int, error)
+	UnreadCount(ctx context.Context, userID string) (int, error)
+	// MarkRead reports false if userID has no such notification.
+	MarkRead(ctx context.Context, userID, id string) (bool, error)
+	// MarkAllRead returns how many notifications were marked.
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	// Preferences returns the types userID has set; unset types are enabled.
+	Preferences(ctx context.Context, userID string) (map[string]bool, error)
+	SetPreferences(ctx context.Context, userID string, prefs map[string]bool) error
 }
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AliasFormatRepo struct{ db *pgxpool.Pool }
+
+func NewAliasFormatRepo(db *pgxpool.Pool) repository.AliasFormatRepository {
+	return &AliasFormatRepo{db: db}
+}
+
+const aliasFormatSelect = `
+		SELECT id, prefix, COALESCE(category, ''), COALESCE(team_id::text, ''),
+			include_year, yearly_reset, padding, counter, active, created_at, updated_at
+		FROM alias_formats`
+
+func scanAliasFormat(row pgx.Row, f *models.AliasFormat) error {
+	return row.Scan(
+		&f.ID, &f.Prefix, &f.Category, &f.TeamID,
+		&f.IncludeYear, &f.YearlyReset, &f.Padding, &f.Counter, &f.Active, &f.CreatedAt, &f.UpdatedAt,
+	)
+}
+
+func (r *AliasFormatRepo) List(ctx context.Context) ([]models.AliasFormat, error) {
+	rows, err := r.db.Query(ctx, aliasFormatSelect+` ORDER BY prefix ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.AliasFormat{}
+	for rows.Next() {
+		var f models.AliasFormat
+		if err := scanAliasFormat(rows, &f); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+func (r *AliasFormatRepo) Get(ctx context.Context, id string) (*models.AliasFormat, error) {
+	var f models.AliasFormat
+	if err := scanAliasFormat(r.db.QueryRow(ctx, aliasFormatSelect+` WHERE id::text = $1`, id), &f); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *AliasFormatRepo) FindConflict(ctx context.Context, f *models.AliasFormat) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		SELECT id::text FROM alias_formats
+		WHERE id::text <> $1
+		  AND (upper(prefix) = upper($2) OR category = $3 OR team_id = $4::uuid)
+		LIMIT 1
+	`, f.ID, f.Prefix, nullIfEmpty(f.Category), nullIfEmpty(f.TeamID)).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *AliasFormatRepo) Create(ctx context.Context, f *models.AliasFormat) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO alias_formats (prefix, category, team_id, include_year, yearly_reset, padding, active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		RETURNING id, counter, created_at, updated_at
+	`,
+		f.Prefix, nullIfEmpty(f.Category), nullIfEmpty(f.TeamID), f.IncludeYear, f.YearlyReset, f.Padding, f.Active,
+	).Scan(&f.ID, &f.Counter, &f.CreatedAt, &f.UpdatedAt)
+}
+
+// Update changes the format; the counter is kept.
+func (r *AliasFormatRepo) Update(ctx context.Context, f *models.AliasFormat) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE alias_formats SET
+			prefix=$1, category=$2, team_id=$3, include_year=$4, yearly_reset=$5, padding=$6, active=$7,
+			updated_at=now()
+		WHERE id=$8
+		RETURNING counter, created_at, updated_at
+	`,
+		f.Prefix, nullIfEmpty(f.Category), nullIfEmpty(f.TeamID), f.IncludeYear, f.YearlyReset, f.Padding, f.Active, f.ID,
+	).Scan(&f.Counter, &f.CreatedAt, &f.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func (r *AliasFormatRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM alias_formats WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
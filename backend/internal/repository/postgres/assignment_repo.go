@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AssignmentRepo struct{ db *pgxpool.Pool }
+
+func NewAssignmentRepo(db *pgxpool.Pool) repository.AssignmentRepository {
+	return &AssignmentRepo{db: db}
+}
+
+const assignmentRuleSelect = `
+		SELECT id, name, position, COALESCE(category, ''), COALESCE(department, ''), strategy,
+			COALESCE(target_user_id::text, ''), active, created_at, updated_at
+		FROM assignment_rules`
+
+func scanAssignmentRule(row pgx.Row, r *models.AssignmentRule) error {
+	return row.Scan(
+		&r.ID, &r.Name, &r.Position, &r.Category, &r.Department, &r.Strategy,
+		&r.TargetUserID, &r.Active, &r.CreatedAt, &r.UpdatedAt,
+	)
+}
+
+func (r *AssignmentRepo) ListRules(ctx context.Context, activeOnly bool) ([]models.AssignmentRule, error) {
+	sql := assignmentRuleSelect
+	if activeOnly {
+		sql += ` WHERE active`
+	}
+	rows, err := r.db.Query(ctx, sql+` ORDER BY position ASC, created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.AssignmentRule{}
+	for rows.Next() {
+		var ar models.AssignmentRule
+		if err := scanAssignmentRule(rows, &ar); err != nil {
+			return nil, err
+		}
+		out = append(out, ar)
+	}
+	return out, rows.Err()
+}
+
+func (r *AssignmentRepo) GetRule(ctx context.Context, id string) (*models.AssignmentRule, error) {
+	var ar models.AssignmentRule
+	if err := scanAssignmentRule(r.db.QueryRow(ctx, assignmentRuleSelect+` WHERE id = $1`, id), &ar); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ar, nil
+}
+
+func (r *AssignmentRepo) CreateRule(ctx context.Context, ar *models.AssignmentRule) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO assignment_rules (name, position, category, department, strategy, target_user_id, active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		RETURNING id, created_at, updated_at
+	`,
+		ar.Name, ar.Position, nullIfEmpty(ar.Category), nullIfEmpty(ar.Department),
+		ar.Strategy, nullIfEmpty(ar.TargetUserID), ar.Active,
+	).Scan(&ar.ID, &ar.CreatedAt, &ar.UpdatedAt)
+}
+
+func (r *AssignmentRepo) UpdateRule(ctx context.Context, ar *models.AssignmentRule) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE assignment_rules SET
+			name=$1, position=$2, category=$3, department=$4, strategy=$5, target_user_id=$6, active=$7,
+			updated_at=now()
+		WHERE id=$8
+		RETURNING created_at, updated_at
+	`,
+		ar.Name, ar.Position, nullIfEmpty(ar.Category), nullIfEmpty(ar.Department),
+		ar.Strategy, nullIfEmpty(ar.TargetUserID), ar.Active, ar.ID,
+	).Scan(&ar.CreatedAt, &ar.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func (r *AssignmentRepo) DeleteRule(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM assignment_rules WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// NextRoundRobin locks the rule row so concurrent creations (even across
+// replicas) hand out agents in turn.
+func (r *AssignmentRepo) NextRoundRobin(ctx context.Context, ruleID string) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var last string
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(last_assigned_user_id::text, '') FROM assignment_rules WHERE id=$1 FOR UPDATE
+	`, ruleID).Scan(&last); err != nil {
+		return "", err
+	}
+
+	// Next agent after the cursor in a stable order, wrapping around.
+	var next string
+	err = tx.QueryRow(ctx, `
+		SELECT id::text FROM users
+		WHERE role = 'agent' AND active
+		ORDER BY (id::text <= $1) ASC, id ASC
+		LIMIT 1
+	`, last).Scan(&next)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE assignment_rules SET last_assigned_user_id=$2 WHERE id=$1`, ruleID, next); err != nil {
+		return "", err
+	}
+	return next, tx.Commit(ctx)
+}
+
+func (r *AssignmentRepo) LeastLoadedAgent(ctx context.Context) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		SELECT u.id::text
+		FROM users u
+		LEFT JOIN tickets t
+		  ON NULLIF(t.assignee, '')::uuid = u.id
+		 AND t.status NOT IN (`+terminalStatuses+`)
+		WHERE u.role = 'agent' AND u.active
+		GROUP BY u.id, u.created_at
+		ORDER BY COUNT(t.id) ASC, u.created_at ASC
+		LIMIT 1
+	`).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *AssignmentRepo) BestSkilledAgent(ctx context.Context, skill string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		SELECT u.id::text
+		FROM users u
+		JOIN user_skills s ON s.user_id = u.id AND s.skill = $1
+		LEFT JOIN tickets t
+		  ON NULLIF(t.assignee, '')::uuid = u.id
+		 AND t.status NOT IN (`+terminalStatuses+`)
+		WHERE u.role = 'agent' AND u.active
+		GROUP BY u.id, u.created_at, s.proficiency
+		ORDER BY s.proficiency DESC, COUNT(t.id) ASC, u.created_at ASC
+		LIMIT 1
+	`, skill).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return id, nil
+}
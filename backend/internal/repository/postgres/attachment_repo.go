@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepo struct{ db *pgxpool.Pool }
+
+func NewAttachmentRepo(db *pgxpool.Pool) repository.AttachmentRepository {
+	return &AttachmentRepo{db: db}
+}
+
+const attachmentSelect = `
+		SELECT
+			a.id, a.ticket_id, COALESCE(a.comment_id::text, ''), COALESCE(a.uploaded_by::text, ''),
+			a.filename, a.content_type, a.size_bytes, a.storage_key, a.created_at,
+			COALESCE(c.internal, FALSE)
+		FROM attachments a
+		LEFT JOIN comments c ON c.id = a.comment_id`
+
+func scanAttachment(row pgx.Row, a *models.Attachment) error {
+	return row.Scan(
+		&a.ID, &a.TicketID, &a.CommentID, &a.UploadedBy,
+		&a.Filename, &a.ContentType, &a.SizeBytes, &a.StorageKey, &a.CreatedAt,
+		&a.Internal,
+	)
+}
+
+func (r *AttachmentRepo) Create(ctx context.Context, a *models.Attachment) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO attachments (id, ticket_id, comment_id, uploaded_by, filename, content_type, size_bytes, storage_key)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING created_at
+	`,
+		a.ID, a.TicketID, nullIfEmpty(a.CommentID), nullIfEmpty(a.UploadedBy),
+		a.Filename, a.ContentType, a.SizeBytes, a.StorageKey,
+	).Scan(&a.CreatedAt)
+}
+
+func (r *AttachmentRepo) Get(ctx context.Context, ticketID, id string) (*models.Attachment, error) {
+	var a models.Attachment
+	err := scanAttachment(r.db.QueryRow(ctx, attachmentSelect+`
+		WHERE a.id = $1 AND a.ticket_id = $2 AND c.deleted_at IS NULL
+	`, id, ticketID), &a)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AttachmentRepo) ListByTicket(ctx context.Context, ticketID string) ([]models.Attachment, error) {
+	rows, err := r.db.Query(ctx, attachmentSelect+`
+		WHERE a.ticket_id = $1 AND c.deleted_at IS NULL
+		ORDER BY a.created_at ASC
+	`, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.Attachment{}
+	for rows.Next() {
+		var a models.Attachment
+		if err := scanAttachment(rows, &a); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *AttachmentRepo) KeyExists(ctx context.Context, key string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM attachments WHERE storage_key = $1)`, key).Scan(&ok)
+	return ok, err
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarRepo struct{ db *pgxpool.Pool }
+
+func NewCalendarRepo(db *pgxpool.Pool) repository.CalendarRepository {
+	return &CalendarRepo{db: db}
+}
+
+const calendarSelect = `
+		SELECT id, name, timezone, hours, COALESCE(department, ''), is_default, created_at, updated_at
+		FROM business_calendars`
+
+func scanCalendar(row pgx.Row, c *models.BusinessCalendar) error {
+	return row.Scan(&c.ID, &c.Name, &c.Timezone, &c.Hours, &c.Department, &c.IsDefault, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *CalendarRepo) List(ctx context.Context) ([]models.BusinessCalendar, error) {
+	rows, err := r.db.Query(ctx, calendarSelect+` ORDER BY is_default DESC, name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.BusinessCalendar{}
+	for rows.Next() {
+		var c models.BusinessCalendar
+		if err := scanCalendar(rows, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range out {
+		if out[i].Holidays, err = r.holidays(ctx, out[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *CalendarRepo) Get(ctx context.Context, id string) (*models.BusinessCalendar, error) {
+	return r.one(ctx, calendarSelect+` WHERE id = $1`, id)
+}
+
+func (r *CalendarRepo) ForDepartment(ctx context.Context, department string) (*models.BusinessCalendar, error) {
+	return r.one(ctx, calendarSelect+`
+		WHERE department = $1 OR is_default
+		ORDER BY (department = $1) DESC NULLS LAST
+		LIMIT 1
+	`, department)
+}
+
+func (r *CalendarRepo) Create(ctx context.Context, c *models.BusinessCalendar) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if c.IsDefault {
+		if _, err := tx.Exec(ctx, `UPDATE business_calendars SET is_default=FALSE WHERE is_default`); err != nil {
+			return err
+		}
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO business_calendars (name, timezone, hours, department, is_default)
+		VALUES ($1,$2,$3,$4,$5)
+		RETURNING id, created_at, updated_at
+	`, c.Name, c.Timezone, c.Hours, nullIfEmpty(c.Department), c.IsDefault).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return err
+	}
+	if err := replaceHolidays(ctx, tx, c.ID, c.Holidays); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *CalendarRepo) Update(ctx context.Context, c *models.BusinessCalendar) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if c.IsDefault {
+		if _, err := tx.Exec(ctx, `UPDATE business_calendars SET is_default=FALSE WHERE is_default AND id<>$1`, c.ID); err != nil {
+			return err
+		}
+	}
+	if err := tx.QueryRow(ctx, `
+		UPDATE business_calendars SET
+			name=$1, timezone=$2, hours=$3, department=$4, is_default=$5, updated_at=now()
+		WHERE id=$6
+		RETURNING created_at, updated_at
+	`, c.Name, c.Timezone, c.Hours, nullIfEmpty(c.Department), c.IsDefault, c.ID).Scan(&c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return repository.ErrNotFound
+		}
+		return err
+	}
+	if err := replaceHolidays(ctx, tx, c.ID, c.Holidays); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *CalendarRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM business_calendars WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CalendarRepo) one(ctx context.Context, sql string, args ...any) (*models.BusinessCalendar, error) {
+	var c models.BusinessCalendar
+	if err := scanCalendar(r.db.QueryRow(ctx, sql, args...), &c); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var err error
+	if c.Holidays, err = r.holidays(ctx, c.ID); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CalendarRepo) holidays(ctx context.Context, calendarID string) ([]models.Holiday, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), name
+		FROM calendar_holidays
+		WHERE calendar_id = $1
+		ORDER BY day ASC
+	`, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.Holiday{}
+	for rows.Next() {
+		var h models.Holiday
+		if err := rows.Scan(&h.Date, &h.Name); err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+
+func replaceHolidays(ctx context.Context, tx pgx.Tx, calendarID string, holidays []models.Holiday) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM calendar_holidays WHERE calendar_id=$1`, calendarID); err != nil {
+		return err
+	}
+	for _, h := range holidays {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO calendar_holidays (calendar_id, day, name)
+			VALUES ($1, $2::date, $3)
+			ON CONFLICT (calendar_id, day) DO UPDATE SET name = EXCLUDED.name
+		`, calendarID, h.Date, h.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
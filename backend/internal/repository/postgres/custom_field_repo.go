@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CustomFieldRepo struct{ db *pgxpool.Pool }
+
+func NewCustomFieldRepo(db *pgxpool.Pool) repository.CustomFieldRepository {
+	return &CustomFieldRepo{db: db}
+}
+
+const customFieldSelect = `
+		SELECT id, key, label, type, options, required, categories, position, active, created_at, updated_at
+		FROM custom_fields`
+
+func scanCustomField(row pgx.Row, f *models.CustomField) error {
+	return row.Scan(
+		&f.ID, &f.Key, &f.Label, &f.Type, &f.Options, &f.Required, &f.Categories,
+		&f.Position, &f.Active, &f.CreatedAt, &f.UpdatedAt,
+	)
+}
+
+func (r *CustomFieldRepo) List(ctx context.Context, activeOnly bool) ([]models.CustomField, error) {
+	sql := customFieldSelect
+	if activeOnly {
+		sql += ` WHERE active`
+	}
+	rows, err := r.db.Query(ctx, sql+` ORDER BY position, key`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.CustomField{}
+	for rows.Next() {
+		var f models.CustomField
+		if err := scanCustomField(rows, &f); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+func (r *CustomFieldRepo) Get(ctx context.Context, id string) (*models.CustomField, error) {
+	var f models.CustomField
+	if err := scanCustomField(r.db.QueryRow(ctx, customFieldSelect+` WHERE id::text = $1`, id), &f); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *CustomFieldRepo) Create(ctx context.Context, f *models.CustomField) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO custom_fields (key, label, type, options, required, categories, position, active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING id, created_at, updated_at
+	`, f.Key, f.Label, f.Type, nonNilStrings(f.Options), f.Required, nonNilStrings(f.Categories), f.Position, f.Active).
+		Scan(&f.ID, &f.CreatedAt, &f.UpdatedAt)
+}
+
+// Update leaves key and type alone: stored values depend on them.
+func (r *CustomFieldRepo) Update(ctx context.Context, f *models.CustomField) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE custom_fields
+		SET label=$1, options=$2, required=$3, categories=$4, position=$5, active=$6, updated_at=now()
+		WHERE id=$7
+		RETURNING key, type, created_at, updated_at
+	`, f.Label, nonNilStrings(f.Options), f.Required, nonNilStrings(f.Categories), f.Position, f.Active, f.ID).
+		Scan(&f.Key, &f.Type, &f.CreatedAt, &f.UpdatedAt)
+}
+
+func (r *CustomFieldRepo) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var key string
+	if err := tx.QueryRow(ctx, `DELETE FROM custom_fields WHERE id=$1 RETURNING key`, id).Scan(&key); err != nil {
+		if err == pgx.ErrNoRows {
+			return repository.ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE tickets SET custom_fields = custom_fields - $1, version=version+1
+		WHERE custom_fields ? $1
+	`, key); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE ticket_templates SET required_fields = array_remove(required_fields, $1)
+		WHERE $1 = ANY(required_fields)
+	`, key); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
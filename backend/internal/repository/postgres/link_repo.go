@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TicketLinkRepo struct{ db *pgxpool.Pool }
+
+func NewTicketLinkRepo(db *pgxpool.Pool) repository.TicketLinkRepository {
+	return &TicketLinkRepo{db: db}
+}
+
+func (r *TicketLinkRepo) List(ctx context.Context, ticketID string) ([]models.TicketLink, error) {
+	return listTicketLinks(ctx, r.db, ticketID)
+}
+
+// listTicketLinks loads ticketID's links oriented from ticketID, joined with
+// the other ticket. Shared with TicketRepo.Get.
+func listTicketLinks(ctx context.Context, db *pgxpool.Pool, ticketID string) ([]models.TicketLink, error) {
+	rows, err := db.Query(ctx, `
+		SELECT l.id, l.kind, l.source_id = $1,
+			o.id, o.alias, o.title, o.status, o.created_by::text,
+			COALESCE(l.created_by::text, ''), l.created_at
+		FROM ticket_links l
+		JOIN tickets o ON o.id = CASE WHEN l.source_id = $1 THEN l.target_id ELSE l.source_id END
+		WHERE l.source_id = $1 OR l.target_id = $1
+		ORDER BY l.kind, l.created_at
+	`, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.TicketLink
+	for rows.Next() {
+		var l models.TicketLink
+		var kind string
+		var outgoing bool
+		if err := rows.Scan(
+			&l.ID, &kind, &outgoing,
+			&l.TicketID, &l.Alias, &l.Title, &l.Status, &l.OtherCreatedBy,
+			&l.CreatedBy, &l.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		l.Type = linkType(kind, outgoing)
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+// linkType names a stored link kind from one end's point of view.
+func linkType(kind string, outgoing bool) string {
+	switch kind {
+	case models.LinkParent:
+		if outgoing {
+			return "parent_of"
+		}
+		return "child_of"
+	case models.LinkBlocks:
+		if outgoing {
+			return "blocks"
+		}
+		return "blocked_by"
+	case models.LinkDuplicate:
+		if outgoing {
+			return "duplicate_of"
+		}
+		return "duplicated_by"
+	}
+	return "relates_to"
+}
+
+func (r *TicketLinkRepo) Create(ctx context.Context, sourceID, targetID, kind, createdBy string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO ticket_links (source_id, target_id, kind, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, sourceID, targetID, kind, nullIfEmpty(createdBy)).Scan(&id)
+	return id, err
+}
+
+func (r *TicketLinkRepo) Delete(ctx context.Context, ticketID, linkID string) error {
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM ticket_links WHERE id = $2 AND (source_id = $1 OR target_id = $1)
+	`, ticketID, linkID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TicketLinkRepo) Exists(ctx context.Context, sourceID, targetID, kind string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM ticket_links WHERE source_id=$1 AND target_id=$2 AND kind=$3)
+	`, sourceID, targetID, kind).Scan(&ok)
+	return ok, err
+}
+
+func (r *TicketLinkRepo) HasParent(ctx context.Context, ticketID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM ticket_links WHERE target_id=$1 AND kind='parent')
+	`, ticketID).Scan(&ok)
+	return ok, err
+}
+
+func (r *TicketLinkRepo) IsAncestor(ctx context.Context, ancestorID, ticketID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `
+		WITH RECURSIVE up(id) AS (
+			SELECT source_id FROM ticket_links WHERE target_id = $2 AND kind = 'parent'
+			UNION
+			SELECT l.source_id FROM ticket_links l JOIN up ON l.target_id = up.id WHERE l.kind = 'parent'
+		)
+		SELECT EXISTS (SELECT 1 FROM up WHERE id = $1)
+	`, ancestorID, ticketID).Scan(&ok)
+	return ok, err
+}
+
+func (r *TicketLinkRepo) OpenChildren(ctx context.Context, ticketID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM ticket_links l
+		JOIN tickets c ON c.id = l.target_id
+		WHERE l.source_id = $1 AND l.kind = 'parent'
+		  AND c.status NOT IN (`+terminalStatuses+`)
+	`, ticketID).Scan(&n)
+	return n, err
+}
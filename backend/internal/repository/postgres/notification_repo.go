@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepo struct{ db *pgxpool.Pool }
+
+func NewNotificationRepo(db *pgxpool.Pool) repository.NotificationRepository {
+	return &NotificationRepo{db: db}
+}
+
+func (r *NotificationRepo) Create(ctx context.Context, n models.Notification, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO notifications (user_id, type, ticket_id, actor_id, message)
+		SELECT r.user_id, $2, $3, $4, $5
+		FROM unnest($1::uuid[]) AS r(user_id)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notification_preferences p
+			WHERE p.user_id = r.user_id AND p.type = $2 AND NOT p.enabled
+		)
+	`, userIDs, n.Type, nullIfEmpty(n.TicketID), nullIfEmpty(n.ActorID), n.Message)
+	return err
+}
+
+func (r *NotificationRepo) List(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]models.Notification, int, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	where := `n.user_id = $1`
+	if unreadOnly {
+		where += ` AND n.read_at IS NULL`
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications n WHERE `+where, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT n.id, n.user_id, n.type, COALESCE(n.ticket_id::text, ''), COALESCE(n.actor_id::text, ''),
+		       n.message, n.read_at, n.created_at,
+		       COALESCE(t.alias, ''), COALESCE(t.title, ''), COALESCE(u.name, '')
+		FROM notifications n
+		LEFT JOIN tickets t ON t.id = n.ticket_id
+		LEFT JOIN users u ON u.id = n.actor_id
+		WHERE `+where+`
+		ORDER BY n.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	out := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		if err := rows.Scan(
+			&n.ID, &n.UserID, &n.Type, &n.TicketID, &n.ActorID, &n.Message, &n.ReadAt, &n.CreatedAt,
+			&n.TicketAlias, &n.TicketTitle, &n.ActorName,
+		); err != nil {
+			return nil, 0, err
+		}
+		out = append(out, n)
+	}
+	return out, total, rows.Err()
+}
+
+func (r *NotificationRepo) UnreadCount(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+// MarkRead leaves an already read notification's read_at alone.
+func (r *NotificationRepo) MarkRead(ctx context.Context, userID, id string) (bool, error) {
+	ct, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, now())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return ct.RowsAffected() > 0, nil
+}
+
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	ct, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = now() WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(ct.RowsAffected()), nil
+}
+
+func (r *NotificationRepo) Preferences(ctx context.Context, userID string) (map[string]bool, error) {
+	rows, err := r.db.Query(ctx, `SELECT type, enabled FROM notification_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]bool{}
+	for rows.Next() {
+		var typ string
+		var enabled bool
+		if err := rows.Scan(&typ, &enabled); err != nil {
+			return nil, err
+		}
+		out[typ] = enabled
+	}
+	return out, rows.Err()
+}
+
+func (r *NotificationRepo) SetPreferences(ctx context.Context, userID string, prefs map[string]bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for typ, enabled := range prefs {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO notification_preferences (user_id, type, enabled) VALUES ($1,$2,$3)
+			ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = now()
+		`, userID, typ, enabled); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SkillRepo struct{ db *pgxpool.Pool }
+
+func NewSkillRepo(db *pgxpool.Pool) repository.SkillRepository { return &SkillRepo{db: db} }
+
+func (r *SkillRepo) List(ctx context.Context, userID string) ([]models.UserSkill, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id::text, skill::text, proficiency, updated_at
+		FROM user_skills
+		WHERE user_id = $1
+		ORDER BY proficiency DESC, skill ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.UserSkill{}
+	for rows.Next() {
+		var s models.UserSkill
+		if err := rows.Scan(&s.UserID, &s.Skill, &s.Proficiency, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *SkillRepo) Upsert(ctx context.Context, s *models.UserSkill) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO user_skills (user_id, skill, proficiency)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, skill) DO UPDATE
+		   SET proficiency = EXCLUDED.proficiency, updated_at = now()
+		RETURNING skill::text, updated_at
+	`, s.UserID, s.Skill, s.Proficiency).Scan(&s.Skill, &s.UpdatedAt)
+}
+
+func (r *SkillRepo) Delete(ctx context.Context, userID, skill string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM user_skills WHERE user_id=$1 AND skill=$2`, userID, skill)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SLAPolicyRepo struct{ db *pgxpool.Pool }
+
+func NewSLAPolicyRepo(db *pgxpool.Pool) repository.SLAPolicyRepository {
+	return &SLAPolicyRepo{db: db}
+}
+
+const slaPolicySelect = `
+		SELECT id, name, priority, COALESCE(category, ''), COALESCE(department, ''),
+			first_response_minutes, resolution_minutes, active, created_at, updated_at
+		FROM sla_policies`
+
+func scanSLAPolicy(row pgx.Row, p *models.SLAPolicy) error {
+	return row.Scan(
+		&p.ID, &p.Name, &p.Priority, &p.Category, &p.Department,
+		&p.FirstResponseMinutes, &p.ResolutionMinutes, &p.Active, &p.CreatedAt, &p.UpdatedAt,
+	)
+}
+
+func (r *SLAPolicyRepo) List(ctx context.Context) ([]models.SLAPolicy, error) {
+	rows, err := r.db.Query(ctx, slaPolicySelect+`
+		ORDER BY priority, category NULLS FIRST, department NULLS FIRST
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.SLAPolicy{}
+	for rows.Next() {
+		var p models.SLAPolicy
+		if err := scanSLAPolicy(rows, &p); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (r *SLAPolicyRepo) Get(ctx context.Context, id string) (*models.SLAPolicy, error) {
+	var p models.SLAPolicy
+	if err := scanSLAPolicy(r.db.QueryRow(ctx, slaPolicySelect+` WHERE id = $1`, id), &p); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *SLAPolicyRepo) GetByScope(ctx context.Context, priority, category, department string) (*models.SLAPolicy, error) {
+	var p models.SLAPolicy
+	err := scanSLAPolicy(r.db.QueryRow(ctx, slaPolicySelect+`
+		WHERE priority = $1 AND COALESCE(category, '') = $2 AND COALESCE(department, '') = $3
+	`, priority, category, department), &p)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *SLAPolicyRepo) Create(ctx context.Context, p *models.SLAPolicy) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO sla_policies (name, priority, category, department, first_response_minutes, resolution_minutes, active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		RETURNING id, created_at, updated_at
+	`,
+		p.Name, p.Priority, nullIfEmpty(p.Category), nullIfEmpty(p.Department),
+		p.FirstResponseMinutes, p.ResolutionMinutes, p.Active,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *SLAPolicyRepo) Update(ctx context.Context, p *models.SLAPolicy) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE sla_policies SET
+			name=$1, priority=$2, category=$3, department=$4,
+			first_response_minutes=$5, resolution_minutes=$6, active=$7, updated_at=now()
+		WHERE id=$8
+		RETURNING updated_at
+	`,
+		p.Name, p.Priority, nullIfEmpty(p.Category), nullIfEmpty(p.Department),
+		p.FirstResponseMinutes, p.ResolutionMinutes, p.Active, p.ID,
+	).Scan(&p.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func (r *SLAPolicyRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM sla_policies WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Match prefers policies that also match category and department over
+// priority-only ones.
+func (r *SLAPolicyRepo) Match(ctx context.Context, priority, category, department string) (*models.SLAPolicy, error) {
+	var p models.SLAPolicy
+	err := scanSLAPolicy(r.db.QueryRow(ctx, slaPolicySelect+`
+		WHERE active
+		  AND priority = $1
+		  AND (category IS NULL OR category = $2)
+		  AND (department IS NULL OR department = $3)
+		ORDER BY (category IS NOT NULL) DESC, (department IS NOT NULL) DESC
+		LIMIT 1
+	`, priority, category, department), &p)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
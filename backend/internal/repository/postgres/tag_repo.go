@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TagRepo struct{ db *pgxpool.Pool }
+
+func NewTagRepo(db *pgxpool.Pool) repository.TagRepository {
+	return &TagRepo{db: db}
+}
+
+const tagSelect = `
+		SELECT id, name::text, color, COALESCE(created_by::text, ''), created_at, updated_at
+		FROM tags`
+
+func scanTag(row pgx.Row, t *models.Tag) error {
+	return row.Scan(&t.ID, &t.Name, &t.Color, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TagRepo) List(ctx context.Context) ([]models.Tag, error) {
+	rows, err := r.db.Query(ctx, tagSelect+` ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.Tag{}
+	for rows.Next() {
+		var t models.Tag
+		if err := scanTag(rows, &t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *TagRepo) Get(ctx context.Context, id string) (*models.Tag, error) {
+	return r.getWhere(ctx, `id::text = $1`, id)
+}
+
+func (r *TagRepo) GetByName(ctx context.Context, name string) (*models.Tag, error) {
+	return r.getWhere(ctx, `name = $1`, name)
+}
+
+func (r *TagRepo) getWhere(ctx context.Context, cond string, arg any) (*models.Tag, error) {
+	var t models.Tag
+	if err := scanTag(r.db.QueryRow(ctx, tagSelect+` WHERE `+cond, arg), &t); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TagRepo) Create(ctx context.Context, t *models.Tag) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tags (name, color, created_by) VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`, t.Name, t.Color, nullIfEmpty(t.CreatedBy)).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TagRepo) Update(ctx context.Context, t *models.Tag) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE tags SET name=$1, color=$2, updated_at=now()
+		WHERE id=$3
+		RETURNING COALESCE(created_by::text, ''), created_at, updated_at
+	`, t.Name, t.Color, t.ID).Scan(&t.CreatedBy, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TagRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM tags WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TagRepo) Attach(ctx context.Context, ticketID string, tag *models.Tag, actorID string) (bool, error) {
+	return r.change(ctx, ticketID, actorID, models.TicketEvent{Field: "tags", NewValue: tag.Name}, `
+		INSERT INTO ticket_tags (ticket_id, tag_id, added_by) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, ticketID, tag.ID, nullIfEmpty(actorID))
+}
+
+func (r *TagRepo) Detach(ctx context.Context, ticketID string, tag *models.Tag, actorID string) (bool, error) {
+	return r.change(ctx, ticketID, actorID, models.TicketEvent{Field: "tags", OldValue: tag.Name}, `
+		DELETE FROM ticket_tags WHERE ticket_id = $1 AND tag_id = $2
+	`, ticketID, tag.ID)
+}
+
+// change runs a ticket_tags statement and, if it affected a row, bumps the
+// ticket version and records ev in the same transaction.
+func (r *TagRepo) change(ctx context.Context, ticketID, actorID string, ev models.TicketEvent, sql string, args ...any) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ct, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	if ct.RowsAffected() == 0 {
+		return false, nil
+	}
+	var at time.Time
+	if err := tx.QueryRow(ctx, `
+		UPDATE tickets SET updated_at=now(), version=version+1 WHERE id=$1 RETURNING updated_at
+	`, ticketID).Scan(&at); err != nil {
+		return false, err
+	}
+	ev.ActorID = actorID
+	if err := insertEvents(ctx, tx, ticketID, at, []models.TicketEvent{ev}); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *TagRepo) Counts(ctx context.Context) ([]models.TagCount, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT g.name::text,
+			COUNT(t.id) FILTER (WHERE t.status NOT IN (`+terminalStatuses+`)),
+			COUNT(t.id)
+		FROM tags g
+		LEFT JOIN ticket_tags tt ON tt.tag_id = g.id
+		LEFT JOIN tickets t ON t.id = tt.ticket_id
+		GROUP BY g.id, g.name
+		ORDER BY COUNT(t.id) DESC, g.name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.TagCount{}
+	for rows.Next() {
+		var c models.TagCount
+		if err := rows.Scan(&c.Tag, &c.Open, &c.Total); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
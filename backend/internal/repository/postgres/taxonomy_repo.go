@@ -0,0 +1,336 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TaxonomyRepo struct{ db *pgxpool.Pool }
+
+func NewTaxonomyRepo(db *pgxpool.Pool) repository.TaxonomyRepository {
+	return &TaxonomyRepo{db: db}
+}
+
+// terminalStatuses selects the names of statuses that end the lifecycle, for
+// use as "t.status NOT IN (" + terminalStatuses + ")".
+const terminalStatuses = `SELECT name FROM ticket_statuses WHERE is_terminal`
+
+// Columns storing taxonomy names, renamed along with the entry. A "[]"
+// suffix marks a TEXT[] column.
+var (
+	statusRefs   = []string{"tickets.status"}
+	priorityRefs = []string{"tickets.priority", "sla_policies.priority", "ticket_templates.priority"}
+	categoryRefs = []string{
+		"tickets.category", "sla_policies.category", "assignment_rules.category", "alias_formats.category",
+		"custom_fields.categories[]", "ticket_templates.category",
+	}
+)
+
+func (r *TaxonomyRepo) Load(ctx context.Context) (*models.Taxonomy, error) {
+	out := &models.Taxonomy{
+		Statuses:   []models.TicketStatus{},
+		Priorities: []models.TicketPriority{},
+		Categories: []models.TicketCategory{},
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, position, color, is_terminal, is_system, next_statuses, active, created_at, updated_at
+		FROM ticket_statuses ORDER BY position, name`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var s models.TicketStatus
+		if err := rows.Scan(&s.ID, &s.Name, &s.Position, &s.Color, &s.Terminal, &s.System, &s.Next,
+			&s.Active, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out.Statuses = append(out.Statuses, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT id, name, position, color, is_default, is_high, active, created_at, updated_at
+		FROM ticket_priorities ORDER BY position, name`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var p models.TicketPriority
+		if err := rows.Scan(&p.ID, &p.Name, &p.Position, &p.Color, &p.Default, &p.High,
+			&p.Active, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out.Priorities = append(out.Priorities, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT id, name, position, color, active, created_at, updated_at
+		FROM ticket_categories ORDER BY position, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c models.TicketCategory
+		if err := rows.Scan(&c.ID, &c.Name, &c.Position, &c.Color, &c.Active, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out.Categories = append(out.Categories, c)
+	}
+	return out, rows.Err()
+}
+
+// -----------------------------------------------------------------------------
+// Statuses
+// -----------------------------------------------------------------------------
+
+func (r *TaxonomyRepo) CreateStatus(ctx context.Context, s *models.TicketStatus) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO ticket_statuses (name, position, color, is_terminal, next_statuses, active)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		RETURNING id, is_system, created_at, updated_at
+	`, s.Name, s.Position, s.Color, s.Terminal, nonNilStrings(s.Next), s.Active).
+		Scan(&s.ID, &s.System, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *TaxonomyRepo) UpdateStatus(ctx context.Context, s *models.TicketStatus) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	old, err := lockTaxonomyName(ctx, tx, "ticket_statuses", s.ID)
+	if err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, `
+		UPDATE ticket_statuses
+		SET name=$1, position=$2, color=$3, is_terminal=$4, next_statuses=$5, active=$6, updated_at=now()
+		WHERE id=$7
+		RETURNING is_system, created_at, updated_at
+	`, s.Name, s.Position, s.Color, s.Terminal, nonNilStrings(s.Next), s.Active, s.ID).
+		Scan(&s.System, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return err
+	}
+	if old != s.Name {
+		if _, err := tx.Exec(ctx, `
+			UPDATE ticket_statuses SET next_statuses = array_replace(next_statuses, $1, $2)
+			WHERE $1 = ANY(next_statuses)
+		`, old, s.Name); err != nil {
+			return err
+		}
+		if err := renameRefs(ctx, tx, statusRefs, old, s.Name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaxonomyRepo) DeleteStatus(ctx context.Context, id string) error {
+	return r.delete(ctx, "ticket_statuses", statusRefs, id, func(tx pgx.Tx, name string) error {
+		_, err := tx.Exec(ctx, `
+			UPDATE ticket_statuses SET next_statuses = array_remove(next_statuses, $1)
+			WHERE $1 = ANY(next_statuses)
+		`, name)
+		return err
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Priorities
+// -----------------------------------------------------------------------------
+
+func (r *TaxonomyRepo) CreatePriority(ctx context.Context, p *models.TicketPriority) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if p.Default {
+		if _, err := tx.Exec(ctx, `UPDATE ticket_priorities SET is_default=FALSE WHERE is_default`); err != nil {
+			return err
+		}
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO ticket_priorities (name, position, color, is_default, is_high, active)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		RETURNING id, created_at, updated_at
+	`, p.Name, p.Position, p.Color, p.Default, p.High, p.Active).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaxonomyRepo) UpdatePriority(ctx context.Context, p *models.TicketPriority) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	old, err := lockTaxonomyName(ctx, tx, "ticket_priorities", p.ID)
+	if err != nil {
+		return err
+	}
+	if p.Default {
+		if _, err := tx.Exec(ctx, `UPDATE ticket_priorities SET is_default=FALSE WHERE is_default AND id <> $1`, p.ID); err != nil {
+			return err
+		}
+	}
+	if err := tx.QueryRow(ctx, `
+		UPDATE ticket_priorities
+		SET name=$1, position=$2, color=$3, is_default=$4, is_high=$5, active=$6, updated_at=now()
+		WHERE id=$7
+		RETURNING created_at, updated_at
+	`, p.Name, p.Position, p.Color, p.Default, p.High, p.Active, p.ID).Scan(&p.CreatedAt, &p.UpdatedAt); err != nil {
+		return err
+	}
+	if old != p.Name {
+		if err := renameRefs(ctx, tx, priorityRefs, old, p.Name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaxonomyRepo) DeletePriority(ctx context.Context, id string) error {
+	return r.delete(ctx, "ticket_priorities", priorityRefs, id, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Categories
+// -----------------------------------------------------------------------------
+
+func (r *TaxonomyRepo) CreateCategory(ctx context.Context, c *models.TicketCategory) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO ticket_categories (name, position, color, active)
+		VALUES ($1,$2,$3,$4)
+		RETURNING id, created_at, updated_at
+	`, c.Name, c.Position, c.Color, c.Active).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *TaxonomyRepo) UpdateCategory(ctx context.Context, c *models.TicketCategory) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	old, err := lockTaxonomyName(ctx, tx, "ticket_categories", c.ID)
+	if err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, `
+		UPDATE ticket_categories SET name=$1, position=$2, color=$3, active=$4, updated_at=now()
+		WHERE id=$5
+		RETURNING created_at, updated_at
+	`, c.Name, c.Position, c.Color, c.Active, c.ID).Scan(&c.CreatedAt, &c.UpdatedAt); err != nil {
+		return err
+	}
+	if old != c.Name {
+		if err := renameRefs(ctx, tx, categoryRefs, old, c.Name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaxonomyRepo) DeleteCategory(ctx context.Context, id string) error {
+	return r.delete(ctx, "ticket_categories", categoryRefs, id, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Helpers (table and column names are constants, never client input)
+// -----------------------------------------------------------------------------
+
+// lockTaxonomyName locks the row id of table and returns its current name
+// (repository.ErrNotFound if there is no such row).
+func lockTaxonomyName(ctx context.Context, tx pgx.Tx, table, id string) (string, error) {
+	var name string
+	err := tx.QueryRow(ctx, `SELECT name FROM `+table+` WHERE id::text = $1 FOR UPDATE`, id).Scan(&name)
+	if err == pgx.ErrNoRows {
+		return "", repository.ErrNotFound
+	}
+	return name, err
+}
+
+// renameRefs rewrites old to name in every "table.column" of refs. Tickets
+// get a new version so cached copies are refreshed.
+func renameRefs(ctx context.Context, tx pgx.Tx, refs []string, old, name string) error {
+	for _, ref := range refs {
+		table, col, _ := strings.Cut(ref, ".")
+		sql := `UPDATE ` + table + ` SET ` + col + `=$2 WHERE ` + col + `=$1`
+		if arr, ok := strings.CutSuffix(col, "[]"); ok {
+			sql = `UPDATE ` + table + ` SET ` + arr + `=array_replace(` + arr + `, $1, $2) WHERE $1 = ANY(` + arr + `)`
+		} else if table == "tickets" {
+			sql = `UPDATE tickets SET ` + col + `=$2, version=version+1 WHERE ` + col + `=$1`
+		}
+		if _, err := tx.Exec(ctx, sql, old, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete removes row id of table unless a column in refs still uses its
+// name; cleanup runs in the same transaction before the delete.
+func (r *TaxonomyRepo) delete(ctx context.Context, table string, refs []string, id string, cleanup func(pgx.Tx, string) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	name, err := lockTaxonomyName(ctx, tx, table, id)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		table, col, _ := strings.Cut(ref, ".")
+		cond := col + ` = $1`
+		if arr, ok := strings.CutSuffix(col, "[]"); ok {
+			cond = `$1 = ANY(` + arr + `)`
+		}
+		var used bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE `+cond+`)`, name).Scan(&used); err != nil {
+			return err
+		}
+		if used {
+			return repository.ErrInUse
+		}
+	}
+	if cleanup != nil {
+		if err := cleanup(tx, name); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TeamRepo struct{ db *pgxpool.Pool }
+
+func NewTeamRepo(db *pgxpool.Pool) repository.TeamRepository { return &TeamRepo{db: db} }
+
+const teamSelect = `
+		SELECT id, name::text, description, created_at, updated_at
+		FROM teams`
+
+func scanTeam(row pgx.Row, t *models.Team) error {
+	return row.Scan(&t.ID, &t.Name, &t.Description, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TeamRepo) List(ctx context.Context) ([]models.Team, error) {
+	rows, err := r.db.Query(ctx, teamSelect+` ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.Team{}
+	for rows.Next() {
+		var t models.Team
+		if err := scanTeam(rows, &t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *TeamRepo) Get(ctx context.Context, id string) (*models.Team, error) {
+	return r.getWhere(ctx, `id::text = $1`, id)
+}
+
+func (r *TeamRepo) GetByName(ctx context.Context, name string) (*models.Team, error) {
+	return r.getWhere(ctx, `name = $1::citext`, name)
+}
+
+func (r *TeamRepo) getWhere(ctx context.Context, cond string, arg any) (*models.Team, error) {
+	var t models.Team
+	if err := scanTeam(r.db.QueryRow(ctx, teamSelect+` WHERE `+cond, arg), &t); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id::text, u.name, u.email, u.role, u.active, m.is_lead
+		FROM team_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.team_id = $1
+		ORDER BY m.is_lead DESC, u.name ASC
+	`, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	t.Members = []models.TeamMember{}
+	for rows.Next() {
+		var m models.TeamMember
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.Role, &m.Active, &m.Lead); err != nil {
+			return nil, err
+		}
+		t.Members = append(t.Members, m)
+	}
+	return &t, rows.Err()
+}
+
+func (r *TeamRepo) Create(ctx context.Context, t *models.Team) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO teams (name, description) VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`, t.Name, t.Description).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TeamRepo) Update(ctx context.Context, t *models.Team) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE teams SET name=$1, description=$2, updated_at=now()
+		WHERE id=$3
+		RETURNING created_at, updated_at
+	`, t.Name, t.Description, t.ID).Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TeamRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM teams WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepo) SetMember(ctx context.Context, teamID, userID string, lead bool) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO team_members (team_id, user_id, is_lead) VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, user_id) DO UPDATE SET is_lead = EXCLUDED.is_lead
+	`, teamID, userID, lead)
+	return err
+}
+
+func (r *TeamRepo) RemoveMember(ctx context.Context, teamID, userID string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM team_members WHERE team_id=$1 AND user_id=$2`, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepo) Membership(ctx context.Context, teamID, userID string) (bool, bool, error) {
+	var lead bool
+	err := r.db.QueryRow(ctx, `
+		SELECT is_lead FROM team_members WHERE team_id::text=$1 AND user_id::text=$2
+	`, teamID, userID).Scan(&lead)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return true, lead, nil
+}
+
+func (r *TeamRepo) OpenLoad(ctx context.Context, teamID string) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT assignee, COUNT(*)
+		FROM tickets
+		WHERE team_id = $1 AND COALESCE(assignee, '') <> ''
+		  AND status NOT IN (`+terminalStatuses+`)
+		GROUP BY assignee
+	`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]int{}
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		out[id] = n
+	}
+	return out, rows.Err()
+}
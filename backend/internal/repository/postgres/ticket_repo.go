@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -33,9 +34,10 @@ func (r *TicketRepo) List(ctx context.Context, q, status string, limit, offset i
 
 	if q = strings.TrimSpace(q); q != "" {
 		p := "%" + q + "%"
-		args = append(args, p, p)
-		// Case-insensitive match on title or description
-		conds = append(conds, "(t.title ILIKE $"+itoa(len(args)-1)+" OR t.description ILIKE $"+itoa(len(args))+")")
+		args = append(args, p, p, aliasPrefix(q))
+		// Case-insensitive match on title or description, or alias prefix
+		conds = append(conds, "(t.title ILIKE $"+itoa(len(args)-2)+" OR t.description ILIKE $"+itoa(len(args)-1)+
+			" OR lower(t.alias) LIKE $"+itoa(len(args))+")")
 	}
 	if status != "" {
 		args = append(args, status)
@@ -43,13 +45,7 @@ func (r *TicketRepo) List(ctx context.Context, q, status string, limit, offset i
 	}
 	args = append(args, limit, offset)
 
-	sql := `
-		SELECT
-			t.id, t.alias, t.title, t.description, t.category, t.priority, t.status,
-			COALESCE(t.assignee, ''), t.department, t.created_by, t.created_at, t.updated_at,
-			COALESCE(u.name, ''), COALESCE(u.email, '')
-		FROM tickets t
-		LEFT JOIN users u ON u.id = NULLIF(t.assignee, '')::uuid
+	sql := ticketSelect + `
 		WHERE ` + strings.Join(conds, " AND ") + `
 		ORDER BY t.updated_at DESC
 		LIMIT $` + itoa(len(args)-1) + ` OFFSET $` + itoa(len(args))
@@ -63,11 +59,7 @@ func (r *TicketRepo) List(ctx context.Context, q, status string, limit, offset i
 	var out []models.Ticket
 	for rows.Next() {
 		var t models.Ticket
-		if err := rows.Scan(
-			&t.ID, &t.Alias, &t.Title, &t.Description, &t.Category, &t.Priority,
-			&t.Status, &t.Assignee, &t.Department, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt,
-			&t.AssigneeName, &t.AssigneeEmail,
-		); err != nil {
+		if err := scanTicket(rows, &t); err != nil {
 			return nil, err
 		}
 		out = append(out, t)
@@ -80,20 +72,18 @@ func (r *TicketRepo) List(ctx context.Context, q, status string, limit, offset i
 // -----------------------------------------------------------------------------
 
 // ListAdv returns a page of tickets filtered by multiple fields and sorted.
-// - q:        free-text search (title/description, ILIKE)
-// - status:   exact
-// - priority: exact
-// - category: exact
-// - assignee: exact
-// - sort:     created_at|updated_at|priority (default updated_at)
-// - order:    asc|desc (default desc)
-// - limit/offset: pagination
-func (r *TicketRepo) ListAdv(
-	ctx context.Context,
-	q, status, priority, category, assignee, sort, order string,
-	limit, offset int,
-) ([]models.Ticket, error) {
-
+// - Q:         free-text search (title/description, ILIKE) or alias prefix
+// - Status, Priority, Category, Assignee, CreatedBy, TeamID: exact
+// - WatcherID: tickets the user watches
+// - Unassigned, OpenOnly: queue views
+// - Tags:      any (default) or all of the names, per TagMatch
+// - CustomFields: values the ticket's custom fields must contain
+// - SLA:       breached|at_risk|ok
+// - Sort:      created_at|updated_at|priority (default updated_at)
+// - Order:     asc|desc (default desc)
+// - Limit/Offset: pagination
+func (r *TicketRepo) ListAdv(ctx context.Context, f repository.TicketFilter) ([]models.Ticket, error) {
+	limit, offset := f.Limit, f.Offset
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
@@ -101,18 +91,12 @@ func (r *TicketRepo) ListAdv(
 		offset = 0
 	}
 
-	whereSQL, args := buildTicketWhere(q, status, priority, category, assignee)
+	whereSQL, args := buildTicketWhere(f)
 
-	sortCol := sanitizeSort(sort, "updated_at")
-	sortOrd := sanitizeOrder(order, "desc")
+	sortCol := sanitizeSort(f.Sort, "updated_at")
+	sortOrd := sanitizeOrder(f.Order, "desc")
 
-	sql := fmt.Sprintf(`
-		SELECT
-			t.id, t.alias, t.title, t.description, t.category, t.priority, t.status,
-			COALESCE(t.assignee, ''), t.department, t.created_by, t.created_at, t.updated_at,
-			COALESCE(u.name, ''), COALESCE(u.email, '')
-		FROM tickets t
-		LEFT JOIN users u ON u.id = NULLIF(t.assignee, '')::uuid
+	sql := fmt.Sprintf(ticketSelect+`
 		%s
 		ORDER BY t.%s %s
 		LIMIT $%d OFFSET $%d
@@ -129,11 +113,7 @@ func (r *TicketRepo) ListAdv(
 	var out []models.Ticket
 	for rows.Next() {
 		var t models.Ticket
-		if err := rows.Scan(
-			&t.ID, &t.Alias, &t.Title, &t.Description, &t.Category, &t.Priority,
-			&t.Status, &t.Assignee, &t.Department, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt,
-			&t.AssigneeName, &t.AssigneeEmail,
-		); err != nil {
+		if err := scanTicket(rows, &t); err != nil {
 			return nil, err
 		}
 		out = append(out, t)
@@ -142,8 +122,8 @@ func (r *TicketRepo) ListAdv(
 }
 
 // CountAdv returns the total number of tickets for the same filter set (for pagination).
-func (r *TicketRepo) CountAdv(ctx context.Context, q, status, priority, category, assignee string) (int, error) {
-	whereSQL, args := buildTicketWhere(q, status, priority, category, assignee)
+func (r *TicketRepo) CountAdv(ctx context.Context, f repository.TicketFilter) (int, error) {
+	whereSQL, args := buildTicketWhere(f)
 	sql := `SELECT COUNT(*) FROM tickets t ` + whereSQL
 
 	var n int
@@ -158,19 +138,9 @@ func (r *TicketRepo) CountAdv(ctx context.Context, q, status, priority, category
 // -----------------------------------------------------------------------------
 func (r *TicketRepo) Get(ctx context.Context, id string) (*models.Ticket, error) {
 	var t models.Ticket
-	err := r.db.QueryRow(ctx, `
-		SELECT
-			t.id, t.alias, t.title, t.description, t.category, t.priority, t.status,
-			t.assignee, t.department, t.created_by, t.created_at, t.updated_at,
-			COALESCE(u.name, ''), COALESCE(u.email, '')
-		FROM tickets t
-		LEFT JOIN users u ON u.id = NULLIF(t.assignee, '')::uuid
+	err := scanTicket(r.db.QueryRow(ctx, ticketSelect+`
 		WHERE t.id = $1
-	`, id).Scan(
-		&t.ID, &t.Alias, &t.Title, &t.Description, &t.Category, &t.Priority,
-		&t.Status, &t.Assignee, &t.Department, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt,
-		&t.AssigneeName, &t.AssigneeEmail,
-	)
+	`, id), &t)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -178,12 +148,10 @@ func (r *TicketRepo) Get(ctx context.Context, id string) (*models.Ticket, error)
 		return nil, err
 	}
 
-	// load comments
-	rows, err := r.db.Query(ctx, `
-		SELECT id, ticket_id, text, created_at
-		FROM comments
-		WHERE ticket_id = $1
-		ORDER BY created_at ASC
+	// load comments (joined with author name/email)
+	rows, err := r.db.Query(ctx, commentSelect+`
+		WHERE c.ticket_id = $1 AND c.deleted_at IS NULL
+		ORDER BY c.created_at ASC
 	`, id)
 	if err != nil {
 		return nil, err
@@ -191,52 +159,511 @@ func (r *TicketRepo) Get(ctx context.Context, id string) (*models.Ticket, error)
 	defer rows.Close()
 	for rows.Next() {
 		var c models.Comment
-		if err := rows.Scan(&c.ID, &c.TicketID, &c.Text, &c.CreatedAt); err != nil {
+		if err := scanComment(rows, &c); err != nil {
 			return nil, err
 		}
 		t.Comments = append(t.Comments, c)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if t.Links, err = listTicketLinks(ctx, r.db, id); err != nil {
+		return nil, err
+	}
 	return &t, nil
 }
 
+// ResolveAlias uses the unique lower(alias) index.
+func (r *TicketRepo) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		SELECT id::text FROM tickets WHERE lower(alias) = lower($1)
+	`, strings.TrimSpace(alias)).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// Create inserts a ticket. If t.CreatedAt is set (e.g. because SLA deadlines
+// were computed from it) it is kept; otherwise now is used.
 func (r *TicketRepo) Create(ctx context.Context, t *models.Ticket) error {
 	now := time.Now()
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
 	err := r.db.QueryRow(ctx, `
-		INSERT INTO tickets (title, description, category, priority, status, assignee, department, created_by, created_at, updated_at)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
-		RETURNING id, alias, created_at, updated_at
+		INSERT INTO tickets (
+			title, description, category, priority, status, assignee, department, created_by, created_at, updated_at,
+			sla_policy_id, first_response_due, resolution_due, team_id, custom_fields
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)
+		RETURNING id, alias, created_at, updated_at, version
 	`,
-		t.Title, t.Description, t.Category, t.Priority, "New", nullIfEmpty(t.Assignee), t.Department, t.CreatedBy, now, now,
-	).Scan(&t.ID, &t.Alias, &t.CreatedAt, &t.UpdatedAt)
+		t.Title, t.Description, t.Category, t.Priority, models.StatusNew, nullIfEmpty(t.Assignee), t.Department, t.CreatedBy, t.CreatedAt, now,
+		nullIfEmpty(t.SLA.PolicyID), t.SLA.FirstResponseDue, t.SLA.ResolutionDue, nullIfEmpty(t.TeamID), customFieldsJSON(t.CustomFields),
+	).Scan(&t.ID, &t.Alias, &t.CreatedAt, &t.UpdatedAt, &t.Version)
 	return err
 }
 
-func (r *TicketRepo) Update(ctx context.Context, t *models.Ticket) error {
+func (r *TicketRepo) Update(ctx context.Context, t *models.Ticket, events []models.TicketEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := updateTicketTx(ctx, tx, t, events); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateBatch applies several ticket updates in one transaction; if any of
+// them fails nothing is written. The error names the failing ticket.
+func (r *TicketRepo) UpdateBatch(ctx context.Context, updates []repository.TicketUpdate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, u := range updates {
+		if err := updateTicketTx(ctx, tx, u.Ticket, u.Events); err != nil {
+			return fmt.Errorf("ticket %s: %w", u.Ticket.ID, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// updateTicketTx writes t (if its version is still current) and its history
+// events inside tx, bumping t.Version.
+func updateTicketTx(ctx context.Context, tx pgx.Tx, t *models.Ticket, events []models.TicketEvent) error {
 	t.UpdatedAt = time.Now()
-	ct, err := r.db.Exec(ctx, `
+	err := tx.QueryRow(ctx, `
 		UPDATE tickets SET
-			title=$1, description=$2, category=$3, priority=$4, status=$5, assignee=$6, department=$7, updated_at=$8
-		WHERE id=$9
+			version=version+1,
+			title=$1, description=$2, category=$3, priority=$4, status=$5, assignee=$6, department=$7, updated_at=$8,
+			resolved_at=$9, closed_at=$10,
+			sla_policy_id=$11, first_response_due=$12, resolution_due=$13, first_responded_at=$14,
+			sla_paused_at=$15, sla_paused_seconds=$16, sla_escalation_level=$17,
+			team_id=$18, custom_fields=$19
+		WHERE id=$20 AND version=$21
+		RETURNING version
 	`,
-		t.Title, t.Description, t.Category, t.Priority, t.Status, nullIfEmpty(t.Assignee), t.Department, t.UpdatedAt, t.ID,
-	)
+		t.Title, t.Description, t.Category, t.Priority, t.Status, nullIfEmpty(t.Assignee), t.Department, t.UpdatedAt,
+		t.ResolvedAt, t.ClosedAt,
+		nullIfEmpty(t.SLA.PolicyID), t.SLA.FirstResponseDue, t.SLA.ResolutionDue, t.SLA.FirstRespondedAt,
+		t.SLA.PausedAt, t.SLA.PausedSeconds, t.SLA.EscalationLevel,
+		nullIfEmpty(t.TeamID), customFieldsJSON(t.CustomFields),
+		t.ID, t.Version,
+	).Scan(&t.Version)
+	if err == pgx.ErrNoRows {
+		// Either the ticket is gone or someone else updated it first
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tickets WHERE id=$1)`, t.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return repository.ErrVersionConflict
+		}
+		return pgx.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+	return insertEvents(ctx, tx, t.ID, t.UpdatedAt, events)
+}
+
+func (r *TicketRepo) AddComment(ctx context.Context, ticketID, authorID, text string, internal bool) (*models.Comment, error) {
+	var id string
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO comments (ticket_id, author_id, text, internal)
+		VALUES ($1,$2,$3,$4)
+		RETURNING id
+	`, ticketID, nullIfEmpty(authorID), text, internal).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	var c models.Comment
+	err := scanComment(r.db.QueryRow(ctx, commentSelect+` WHERE c.id = $1`, id), &c)
+	return &c, err
+}
+
+// ClaimTicket relies on the row lock taken by UPDATE: a concurrent claim
+// re-evaluates the WHERE clause after the first commits and matches nothing.
+func (r *TicketRepo) ClaimTicket(ctx context.Context, ticketID, userID string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var at time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE tickets t SET assignee=$2, updated_at=now(), version=t.version+1
+		WHERE t.id = $1
+		  AND COALESCE(t.assignee, '') = ''
+		  AND t.status NOT IN (`+terminalStatuses+`)
+		  AND (t.team_id IS NULL OR EXISTS (
+		        SELECT 1 FROM team_members m WHERE m.team_id = t.team_id AND m.user_id = $2::uuid))
+		RETURNING t.updated_at
+	`, ticketID, userID).Scan(&at)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := insertEvents(ctx, tx, ticketID, at, []models.TicketEvent{{
+		ActorID:  userID,
+		Field:    "assignee",
+		NewValue: userID,
+	}}); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *TicketRepo) ReleaseTicket(ctx context.Context, ticketID, assignee, actorID string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var old string
+	var at time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE tickets t SET assignee=NULL, updated_at=now(), version=t.version+1
+		FROM (SELECT id, assignee FROM tickets WHERE id = $1 FOR UPDATE) prev
+		WHERE t.id = prev.id
+		  AND COALESCE(prev.assignee, '') <> ''
+		  AND ($2 = '' OR prev.assignee = $2)
+		RETURNING prev.assignee, t.updated_at
+	`, ticketID, assignee).Scan(&old, &at)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := insertEvents(ctx, tx, ticketID, at, []models.TicketEvent{{
+		ActorID:  actorID,
+		Field:    "assignee",
+		OldValue: old,
+	}}); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// MergeTicket runs in one transaction. The source row is updated only if it
+// has not been merged yet, which also serialises concurrent merges.
+func (r *TicketRepo) MergeTicket(ctx context.Context, sourceID, targetID, actorID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var oldStatus string
+	var at time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE tickets t SET
+			merged_into_id = $2,
+			status = $3,
+			resolved_at = COALESCE(t.resolved_at, now()),
+			closed_at = now(),
+			sla_paused_at = NULL,
+			updated_at = now(),
+			version = t.version + 1
+		FROM (SELECT id, status FROM tickets WHERE id = $1 FOR UPDATE) prev
+		WHERE t.id = prev.id AND t.merged_into_id IS NULL
+		RETURNING prev.status, t.updated_at
+	`, sourceID, targetID, models.StatusClosed).Scan(&oldStatus, &at)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return repository.ErrVersionConflict
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE comments SET ticket_id=$2 WHERE ticket_id=$1`, sourceID, targetID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE attachments SET ticket_id=$2 WHERE ticket_id=$1`, sourceID, targetID); err != nil {
+		return err
+	}
+	// the duplicate's watchers keep following the surviving ticket if they
+	// may read it (end users only see their own tickets)
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO ticket_watchers (ticket_id, user_id, source, added_by)
+		SELECT $2, w.user_id, 'follow', w.added_by
+		FROM ticket_watchers w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.ticket_id = $1
+		  AND (u.role <> 'end_user' OR u.id = (SELECT created_by FROM tickets WHERE id = $2))
+		ON CONFLICT DO NOTHING
+	`, sourceID, targetID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO ticket_links (source_id, target_id, kind, created_by)
+		VALUES ($1, $2, 'duplicate', $3)
+		ON CONFLICT DO NOTHING
+	`, sourceID, targetID, nullIfEmpty(actorID)); err != nil {
+		return err
+	}
+	ct, err := tx.Exec(ctx, `
+		UPDATE tickets SET updated_at=$2, version=version+1 WHERE id=$1 AND merged_into_id IS NULL
+	`, targetID, at)
 	if err != nil {
 		return err
 	}
 	if ct.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return repository.ErrVersionConflict
 	}
-	return nil
+
+	events := []models.TicketEvent{{
+		TicketID: sourceID, ActorID: actorID, Type: models.TicketEventMerge,
+		Field: "merged_into", NewValue: targetID,
+	}}
+	if oldStatus != models.StatusClosed {
+		events = append(events, models.TicketEvent{
+			TicketID: sourceID, ActorID: actorID,
+			Field: "status", OldValue: oldStatus, NewValue: models.StatusClosed,
+		})
+	}
+	events = append(events, models.TicketEvent{
+		TicketID: targetID, ActorID: actorID, Type: models.TicketEventMerge,
+		Field: "merged_from", NewValue: sourceID,
+	})
+	if err := insertEvents(ctx, tx, sourceID, at, events); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListSLAEscalations returns open, running (not paused) tickets that are
+// breached or at risk and have not been escalated to that level yet, most
+// urgent first.
+func (r *TicketRepo) ListSLAEscalations(ctx context.Context, limit int) ([]models.Ticket, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := r.db.Query(ctx, ticketSelect+`
+		WHERE t.status NOT IN (`+terminalStatuses+`)
+		  AND t.sla_paused_at IS NULL
+		  AND ((`+slaBreached+` AND t.sla_escalation_level < 2)
+		    OR (`+slaAtRisk+` AND t.sla_escalation_level < 1))
+		ORDER BY LEAST(t.first_response_due, t.resolution_due) ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.Ticket
+	for rows.Next() {
+		var t models.Ticket
+		if err := scanTicket(rows, &t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
 }
 
-func (r *TicketRepo) AddComment(ctx context.Context, ticketID string, text string) (*models.Comment, error) {
+// MarkFirstResponse stamps first_responded_at unless it is already set.
+func (r *TicketRepo) MarkFirstResponse(ctx context.Context, ticketID string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE tickets SET first_responded_at=$2, version=version+1
+		WHERE id=$1 AND first_responded_at IS NULL
+	`, ticketID, at)
+	return err
+}
+
+func (r *TicketRepo) GetComment(ctx context.Context, ticketID, commentID string) (*models.Comment, error) {
 	var c models.Comment
-	err := r.db.QueryRow(ctx, `
-		INSERT INTO comments (ticket_id, text)
-		VALUES ($1,$2)
-		RETURNING id, ticket_id, text, created_at
-	`, ticketID, text).Scan(&c.ID, &c.TicketID, &c.Text, &c.CreatedAt)
-	return &c, err
+	err := scanComment(r.db.QueryRow(ctx, commentSelect+`
+		WHERE c.id = $1 AND c.ticket_id = $2 AND c.deleted_at IS NULL
+	`, commentID, ticketID), &c)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TicketRepo) UpdateComment(ctx context.Context, commentID, editorID, text string) (*models.Comment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Keep the current text as a revision before overwriting it
+	ct, err := tx.Exec(ctx, `
+		INSERT INTO comment_revisions (comment_id, text, edited_by)
+		SELECT id, text, $2 FROM comments WHERE id = $1 AND deleted_at IS NULL
+	`, commentID, nullIfEmpty(editorID))
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE comments SET text=$1, edited_at=now() WHERE id=$2
+	`, text, commentID); err != nil {
+		return nil, err
+	}
+
+	var c models.Comment
+	if err := scanComment(tx.QueryRow(ctx, commentSelect+` WHERE c.id = $1`, commentID), &c); err != nil {
+		return nil, err
+	}
+	return &c, tx.Commit(ctx)
+}
+
+func (r *TicketRepo) DeleteComment(ctx context.Context, commentID, actorID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var ticketID string
+	var at time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE comments SET deleted_at=now(), deleted_by=$2
+		WHERE id=$1 AND deleted_at IS NULL
+		RETURNING ticket_id, deleted_at
+	`, commentID, nullIfEmpty(actorID)).Scan(&ticketID, &at)
+	if err != nil {
+		return err
+	}
+	if err := insertEvents(ctx, tx, ticketID, at, []models.TicketEvent{{
+		ActorID:  actorID,
+		Type:     models.TicketEventCommentDeleted,
+		Field:    "comment",
+		OldValue: commentID,
+	}}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TicketRepo) SetCommentMentions(ctx context.Context, commentID string, mentions []models.Mention) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM comment_mentions WHERE comment_id=$1`, commentID); err != nil {
+		return err
+	}
+	for _, m := range mentions {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO comment_mentions (comment_id, user_id, handle) VALUES ($1,$2,$3)
+			ON CONFLICT DO NOTHING
+		`, commentID, m.UserID, m.Handle); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CommentRevisions returns prior versions of a comment, oldest first.
+func (r *TicketRepo) CommentRevisions(ctx context.Context, commentID string) ([]models.CommentRevision, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, comment_id, text, COALESCE(edited_by::text, ''), created_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY created_at ASC
+	`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.CommentRevision{}
+	for rows.Next() {
+		var rv models.CommentRevision
+		if err := rows.Scan(&rv.ID, &rv.CommentID, &rv.Text, &rv.EditedBy, &rv.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, rv)
+	}
+	return out, rows.Err()
+}
+
+// -----------------------------------------------------------------------------
+// History (audit trail)
+// -----------------------------------------------------------------------------
+
+// History returns a ticket's change events, oldest first, joined with actor name/email.
+func (r *TicketRepo) History(ctx context.Context, ticketID string) ([]models.TicketEvent, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT
+			e.id, e.ticket_id, COALESCE(e.actor_id::text, ''), e.event_type, e.field,
+			COALESCE(e.old_value, ''), COALESCE(e.new_value, ''), e.created_at,
+			COALESCE(u.name, ''), COALESCE(u.email, '')
+		FROM ticket_events e
+		LEFT JOIN users u ON u.id = e.actor_id
+		WHERE e.ticket_id = $1
+		ORDER BY e.created_at ASC, e.id ASC
+	`, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.TicketEvent{}
+	for rows.Next() {
+		var e models.TicketEvent
+		if err := rows.Scan(
+			&e.ID, &e.TicketID, &e.ActorID, &e.Type, &e.Field,
+			&e.OldValue, &e.NewValue, &e.CreatedAt,
+			&e.ActorName, &e.ActorEmail,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// insertEvents appends history rows inside an open transaction. Events without
+// a timestamp are stamped with at.
+func insertEvents(ctx context.Context, tx pgx.Tx, ticketID string, at time.Time, events []models.TicketEvent) error {
+	for _, e := range events {
+		if e.TicketID == "" {
+			e.TicketID = ticketID
+		}
+		if e.Type == "" {
+			e.Type = models.TicketEventChange
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = at
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO ticket_events (ticket_id, actor_id, event_type, field, old_value, new_value, created_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7)
+		`, e.TicketID, nullIfEmpty(e.ActorID), e.Type, e.Field, e.OldValue, e.NewValue, e.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -258,9 +685,9 @@ func (r *TicketRepo) CountByStatus(ctx context.Context, statuses []string, inclu
 	return n, nil
 }
 
-// CountResolvedSince counts tickets resolved/closed since the provided time.
+// CountResolvedSince counts tickets in a terminal status resolved since the provided time.
 func (r *TicketRepo) CountResolvedSince(ctx context.Context, since time.Time) (int, error) {
-	sql := `SELECT COUNT(*) FROM tickets WHERE status IN ('Resolved','Closed') AND updated_at >= $1`
+	sql := `SELECT COUNT(*) FROM tickets WHERE status IN (` + terminalStatuses + `) AND COALESCE(resolved_at, updated_at) >= $1`
 	var n int
 	if err := r.db.QueryRow(ctx, sql, since).Scan(&n); err != nil {
 		return 0, err
@@ -268,9 +695,9 @@ func (r *TicketRepo) CountResolvedSince(ctx context.Context, since time.Time) (i
 	return n, nil
 }
 
-// CountOpenByPriorities counts open tickets (not Resolved/Closed) with given priorities.
+// CountOpenByPriorities counts open tickets (not in a terminal status) with given priorities.
 func (r *TicketRepo) CountOpenByPriorities(ctx context.Context, prios []string) (int, error) {
-	sql := `SELECT COUNT(*) FROM tickets WHERE status NOT IN ('Resolved','Closed') AND priority = ANY($1)`
+	sql := `SELECT COUNT(*) FROM tickets WHERE status NOT IN (` + terminalStatuses + `) AND priority = ANY($1)`
 	var n int
 	if err := r.db.QueryRow(ctx, sql, prios).Scan(&n); err != nil {
 		return 0, err
@@ -282,37 +709,154 @@ func (r *TicketRepo) CountOpenByPriorities(ctx context.Context, prios []string)
 // Helpers
 // -----------------------------------------------------------------------------
 
+// SLA predicates over the tickets alias t. While a ticket is paused the clock
+// is frozen at sla_paused_at. "At risk" means less than a quarter of the
+// window between creation and the deadline remains.
+const (
+	slaNow                   = `COALESCE(t.sla_paused_at, now())`
+	slaFirstResponseBreached = `(t.first_response_due IS NOT NULL AND COALESCE(t.first_responded_at, ` + slaNow + `) > t.first_response_due)`
+	slaResolutionBreached    = `(t.resolution_due IS NOT NULL AND COALESCE(t.resolved_at, ` + slaNow + `) > t.resolution_due)`
+	slaBreached              = `(` + slaFirstResponseBreached + ` OR ` + slaResolutionBreached + `)`
+	slaAtRisk                = `(NOT ` + slaBreached + ` AND (
+		(t.first_responded_at IS NULL AND t.first_response_due IS NOT NULL
+			AND t.first_response_due - ` + slaNow + ` < (t.first_response_due - t.created_at) / 4)
+		OR (t.resolved_at IS NULL AND t.resolution_due IS NOT NULL
+			AND t.resolution_due - ` + slaNow + ` < (t.resolution_due - t.created_at) / 4)))`
+)
+
+// ticketSelect is the shared projection for ticket reads (joined with assignee
+// name/email and team name). Callers append WHERE/ORDER/LIMIT and scan with scanTicket.
+const ticketSelect = `
+		SELECT
+			t.id, t.alias, t.title, t.description, t.category, t.priority, t.status,
+			COALESCE(t.assignee, ''), COALESCE(t.department, ''), COALESCE(t.team_id::text, ''), t.created_by, t.created_at, t.updated_at, t.version,
+			t.resolved_at, t.closed_at, COALESCE(t.merged_into_id::text, ''),
+			COALESCE(t.sla_policy_id::text, ''), t.first_response_due, t.resolution_due, t.first_responded_at,
+			t.sla_paused_at, t.sla_paused_seconds, t.sla_escalation_level,
+			` + slaFirstResponseBreached + `, ` + slaResolutionBreached + `, ` + slaAtRisk + `,
+			COALESCE(u.name, ''), COALESCE(u.email, ''), COALESCE(tm.name::text, ''),
+			ARRAY(SELECT g.name::text FROM ticket_tags tt JOIN tags g ON g.id = tt.tag_id
+			      WHERE tt.ticket_id = t.id ORDER BY g.name),
+			t.custom_fields
+		FROM tickets t
+		LEFT JOIN users u ON u.id = NULLIF(t.assignee, '')::uuid
+		LEFT JOIN teams tm ON tm.id = t.team_id`
+
+// scanTicket scans one row produced by ticketSelect.
+func scanTicket(row pgx.Row, t *models.Ticket) error {
+	return row.Scan(
+		&t.ID, &t.Alias, &t.Title, &t.Description, &t.Category, &t.Priority,
+		&t.Status, &t.Assignee, &t.Department, &t.TeamID, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt, &t.Version,
+		&t.ResolvedAt, &t.ClosedAt, &t.MergedInto,
+		&t.SLA.PolicyID, &t.SLA.FirstResponseDue, &t.SLA.ResolutionDue, &t.SLA.FirstRespondedAt,
+		&t.SLA.PausedAt, &t.SLA.PausedSeconds, &t.SLA.EscalationLevel,
+		&t.SLA.FirstResponseBreached, &t.SLA.ResolutionBreached, &t.SLA.AtRisk,
+		&t.AssigneeName, &t.AssigneeEmail, &t.TeamName, &t.Tags,
+		&t.CustomFields,
+	)
+}
+
+// commentSelect is the shared projection for comment reads (joined with author).
+const commentSelect = `
+		SELECT
+			c.id, c.ticket_id, COALESCE(c.author_id::text, ''), c.text, c.internal, c.created_at, c.edited_at,
+			COALESCE(u.name, ''), COALESCE(u.email, ''),
+			COALESCE((
+				SELECT json_agg(json_build_object('userId', m.user_id, 'name', mu.name, 'email', mu.email, 'handle', m.handle)
+					ORDER BY m.created_at, mu.name)
+				FROM comment_mentions m JOIN users mu ON mu.id = m.user_id
+				WHERE m.comment_id = c.id
+			), '[]')
+		FROM comments c
+		LEFT JOIN users u ON u.id = c.author_id`
+
+// scanComment scans one row produced by commentSelect.
+func scanComment(row pgx.Row, c *models.Comment) error {
+	return row.Scan(
+		&c.ID, &c.TicketID, &c.AuthorID, &c.Text, &c.Internal, &c.CreatedAt, &c.EditedAt,
+		&c.AuthorName, &c.AuthorEmail, &c.Mentions,
+	)
+}
+
 // buildTicketWhere composes WHERE clause and args for advanced filters (with aliases).
-func buildTicketWhere(q, status, priority, category, assignee string) (string, []any) {
+func buildTicketWhere(f repository.TicketFilter) (string, []any) {
 	clauses := []string{"1=1"}
 	args := []any{}
 
-	// free-text search (ILIKE)
-	if s := strings.TrimSpace(q); s != "" {
+	// free-text search (ILIKE) or alias prefix ("TKT-2025-0004")
+	if s := strings.TrimSpace(f.Q); s != "" {
 		p := "%" + s + "%"
-		args = append(args, p, p)
-		clauses = append(clauses, "(t.title ILIKE $"+itoa(len(args)-1)+" OR t.description ILIKE $"+itoa(len(args))+")")
+		args = append(args, p, p, aliasPrefix(s))
+		clauses = append(clauses, "(t.title ILIKE $"+itoa(len(args)-2)+" OR t.description ILIKE $"+itoa(len(args)-1)+
+			" OR lower(t.alias) LIKE $"+itoa(len(args))+")")
 	}
 
 	// exact filters
-	if s := strings.TrimSpace(status); s != "" {
+	if s := strings.TrimSpace(f.Status); s != "" {
 		args = append(args, s)
 		clauses = append(clauses, "t.status = $"+itoa(len(args)))
 	}
-	if p := strings.TrimSpace(priority); p != "" {
+	if p := strings.TrimSpace(f.Priority); p != "" {
 		args = append(args, p)
 		clauses = append(clauses, "t.priority = $"+itoa(len(args)))
 	}
-	if c := strings.TrimSpace(category); c != "" {
+	if c := strings.TrimSpace(f.Category); c != "" {
 		args = append(args, c)
 		clauses = append(clauses, "t.category = $"+itoa(len(args)))
 	}
-	if a := strings.TrimSpace(assignee); a != "" {
+	if a := strings.TrimSpace(f.Assignee); a != "" {
 		args = append(args, a)
 		// Cast assignee to UUID for comparison since it's stored as TEXT but represents a UUID
 		// Handle NULL assignee values by checking both NULL and UUID cast
 		clauses = append(clauses, "(NULLIF(t.assignee,'')::uuid = $"+itoa(len(args))+"::uuid)")
 	}
+	if c := strings.TrimSpace(f.CreatedBy); c != "" {
+		args = append(args, c)
+		clauses = append(clauses, "t.created_by = $"+itoa(len(args))+"::uuid")
+	}
+
+	if id := strings.TrimSpace(f.TeamID); id != "" {
+		args = append(args, id)
+		clauses = append(clauses, "t.team_id = $"+itoa(len(args))+"::uuid")
+	}
+	if w := strings.TrimSpace(f.WatcherID); w != "" {
+		args = append(args, w)
+		clauses = append(clauses, "EXISTS (SELECT 1 FROM ticket_watchers w WHERE w.ticket_id = t.id AND w.user_id = $"+itoa(len(args))+"::uuid)")
+	}
+	if f.Unassigned {
+		clauses = append(clauses, "COALESCE(t.assignee, '') = ''")
+	}
+	if f.OpenOnly {
+		clauses = append(clauses, "t.status NOT IN ("+terminalStatuses+")")
+	}
+
+	// tags: any (default) or all of the given names
+	if tags := normalizeTags(f.Tags); len(tags) > 0 {
+		args = append(args, tags)
+		match := `(SELECT COUNT(*) FROM ticket_tags tt JOIN tags g ON g.id = tt.tag_id
+			WHERE tt.ticket_id = t.id AND lower(g.name::text) = ANY($` + itoa(len(args)) + `::text[]))`
+		if strings.EqualFold(strings.TrimSpace(f.TagMatch), "all") {
+			clauses = append(clauses, match+" = "+itoa(len(tags)))
+		} else {
+			clauses = append(clauses, match+" > 0")
+		}
+	}
+
+	// custom field values: containment, served by the GIN index
+	if len(f.CustomFields) > 0 {
+		args = append(args, customFieldsJSON(f.CustomFields))
+		clauses = append(clauses, "t.custom_fields @> $"+itoa(len(args))+"::jsonb")
+	}
+
+	// SLA state (computed, see slaBreached/slaAtRisk)
+	switch strings.TrimSpace(f.SLA) {
+	case "breached":
+		clauses = append(clauses, slaBreached)
+	case "at_risk":
+		clauses = append(clauses, slaAtRisk)
+	case "ok":
+		clauses = append(clauses, "NOT "+slaBreached+" AND NOT "+slaAtRisk)
+	}
 
 	return "WHERE " + strings.Join(clauses, " AND "), args
 }
@@ -335,6 +879,38 @@ func sanitizeOrder(o, def string) string {
 	}
 }
 
+// aliasPrefix turns s into a LIKE pattern matching aliases that start with
+// it (lowercased, wildcards escaped) for the text_pattern_ops index.
+func aliasPrefix(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(s))
+	return s + "%"
+}
+
+// customFieldsJSON returns m for a JSONB parameter (pgx encodes maps as
+// JSON); nil becomes an empty object since the column is NOT NULL.
+func customFieldsJSON(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+// normalizeTags lowercases, trims and de-duplicates tag names so the "all"
+// match can compare counts.
+func normalizeTags(in []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, t := range in {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
 func nullIfEmpty(s string) any {
 	if strings.TrimSpace(s) == "" {
 		return nil
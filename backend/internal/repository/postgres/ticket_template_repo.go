@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TicketTemplateRepo struct{ db *pgxpool.Pool }
+
+func NewTicketTemplateRepo(db *pgxpool.Pool) repository.TicketTemplateRepository {
+	return &TicketTemplateRepo{db: db}
+}
+
+const ticketTemplateSelect = `
+		SELECT id, name, category, title_pattern, description, COALESCE(priority,''), required_fields,
+		       position, active, created_at, updated_at
+		FROM ticket_templates`
+
+func scanTicketTemplate(row pgx.Row, t *models.TicketTemplate) error {
+	return row.Scan(
+		&t.ID, &t.Name, &t.Category, &t.TitlePattern, &t.Description, &t.Priority, &t.RequiredFields,
+		&t.Position, &t.Active, &t.CreatedAt, &t.UpdatedAt,
+	)
+}
+
+func (r *TicketTemplateRepo) List(ctx context.Context, category string, activeOnly bool) ([]models.TicketTemplate, error) {
+	sql := ticketTemplateSelect + ` WHERE ($1 = '' OR category = $1)`
+	if activeOnly {
+		sql += ` AND active`
+	}
+	rows, err := r.db.Query(ctx, sql+` ORDER BY category, position, name`, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.TicketTemplate{}
+	for rows.Next() {
+		var t models.TicketTemplate
+		if err := scanTicketTemplate(rows, &t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (r *TicketTemplateRepo) Get(ctx context.Context, id string) (*models.TicketTemplate, error) {
+	var t models.TicketTemplate
+	if err := scanTicketTemplate(r.db.QueryRow(ctx, ticketTemplateSelect+` WHERE id::text = $1`, id), &t); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TicketTemplateRepo) Create(ctx context.Context, t *models.TicketTemplate) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO ticket_templates (name, category, title_pattern, description, priority, required_fields, position, active)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING id, created_at, updated_at
+	`, t.Name, t.Category, t.TitlePattern, t.Description, nullIfEmpty(t.Priority), nonNilStrings(t.RequiredFields),
+		t.Position, t.Active).
+		Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TicketTemplateRepo) Update(ctx context.Context, t *models.TicketTemplate) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE ticket_templates
+		SET name=$1, category=$2, title_pattern=$3, description=$4, priority=$5, required_fields=$6,
+		    position=$7, active=$8, updated_at=now()
+		WHERE id=$9
+		RETURNING created_at, updated_at
+	`, t.Name, t.Category, t.TitlePattern, t.Description, nullIfEmpty(t.Priority), nonNilStrings(t.RequiredFields),
+		t.Position, t.Active, t.ID).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *TicketTemplateRepo) Delete(ctx context.Context, id string) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM ticket_templates WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
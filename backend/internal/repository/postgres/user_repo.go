@@ -193,5 +193,53 @@ func (r *UserRepo) FirstActiveAdminID(ctx context.Context) (string, error) {
 	return id, nil
 }
 
+// FirstActiveIDByRole returns the oldest active user with the given role (or ErrNoActiveUser).
+func (r *UserRepo) FirstActiveIDByRole(ctx context.Context, role string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		SELECT id
+		FROM users
+		WHERE role = $1 AND active = TRUE
+		ORDER BY created_at ASC
+		LIMIT 1
+	`, role).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", repository.ErrNoActiveUser
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *UserRepo) FindByMention(ctx context.Context, handle string) ([]models.User, error) {
+	sql := `
+		SELECT id, email, name, role, active, created_at, updated_at
+		FROM users
+		WHERE active = TRUE AND lower(regexp_replace(name, '\s+', '', 'g')) = lower($1)
+		ORDER BY created_at ASC`
+	if strings.Contains(handle, "@") {
+		sql = `
+		SELECT id, email, name, role, active, created_at, updated_at
+		FROM users
+		WHERE active = TRUE AND lower(email) = lower($1)`
+	}
+	rows, err := r.db.Query(ctx, sql, handle)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Role, &u.Active, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
 // note: itoa helper comes from ticket_repo.go in same package
 // func itoa(i int) string { return strconv.Itoa(i) }
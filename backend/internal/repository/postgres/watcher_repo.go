@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WatcherRepo struct{ db *pgxpool.Pool }
+
+func NewWatcherRepo(db *pgxpool.Pool) repository.WatcherRepository {
+	return &WatcherRepo{db: db}
+}
+
+func (r *WatcherRepo) List(ctx context.Context, ticketID string) ([]models.Watcher, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT w.ticket_id, w.user_id, u.name, u.email, w.source, COALESCE(w.added_by::text, ''), w.created_at
+		FROM ticket_watchers w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.ticket_id = $1
+		ORDER BY w.created_at, u.name
+	`, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.Watcher{}
+	for rows.Next() {
+		var w models.Watcher
+		if err := rows.Scan(&w.TicketID, &w.UserID, &w.Name, &w.Email, &w.Source, &w.AddedBy, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+func (r *WatcherRepo) Users(ctx context.Context, ticketID string) ([]models.User, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.email, u.name, u.role, u.active, u.created_at, u.updated_at
+		FROM ticket_watchers w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.ticket_id = $1 AND u.active
+	`, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Role, &u.Active, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+func (r *WatcherRepo) Add(ctx context.Context, ticketID, userID, source, addedBy string) (bool, error) {
+	ct, err := r.db.Exec(ctx, `
+		INSERT INTO ticket_watchers (ticket_id, user_id, source, added_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`, ticketID, userID, source, nullIfEmpty(addedBy))
+	if err != nil {
+		return false, err
+	}
+	return ct.RowsAffected() > 0, nil
+}
+
+func (r *WatcherRepo) Remove(ctx context.Context, ticketID, userID string) (bool, error) {
+	ct, err := r.db.Exec(ctx, `DELETE FROM ticket_watchers WHERE ticket_id=$1 AND user_id=$2`, ticketID, userID)
+	if err != nil {
+		return false, err
+	}
+	return ct.RowsAffected() > 0, nil
+}
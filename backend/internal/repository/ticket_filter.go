@@ -1,13 +1,24 @@
 package repository
 
 type TicketFilter struct {
-	Q        string
-	Status   string
-	Priority string
-	Category string
-	Assignee string
-	Limit    int
-	Offset   int
-	Sort     string // created_at, updated_at, priority
-	Order    string // asc|desc
+	Q          string
+	Status     string
+	Priority   string
+	Category   string
+	Assignee   string
+	CreatedBy  string   // restricts to one creator (end-user visibility)
+	SLA        string   // breached|at_risk|ok
+	TeamID     string   // restricts to one team queue
+	WatcherID  string   // only tickets this user watches
+	Unassigned bool     // only tickets without an individual assignee
+	OpenOnly   bool     // excludes terminal statuses
+	Tags       []string // tag names (case-insensitive)
+	TagMatch   string   // any (default) | all
+	// CustomFields maps field keys to values the ticket must contain (a
+	// multi_select value is given as a one-element list).
+	CustomFields map[string]any
+	Limit        int
+	Offset       int
+	Sort         string // created_at, updated_at, priority
+	Order        string // asc|desc
 }
@@ -7,17 +7,14 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httprate"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 
 	"gh-ts/internal/config"
 	"gh-ts/internal/handlers"
 	"gh-ts/internal/middleware"
-	"gh-ts/internal/repository/postgres"
-	"gh-ts/internal/service"
 )
 
-func New(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) http.Handler {
+func New(log zerolog.Logger, svc *Services, cfg config.Config) http.Handler {
 	r := chi.NewRouter()
 
 	// Core middleware (order: recover -> logging -> cors -> rate-limit -> auth)
@@ -26,7 +23,8 @@ func New(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{cfg.Origin},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match"},
+		ExposedHeaders:   []string{"ETag", "X-Total-Count"},
 		AllowCredentials: true,
 	}))
 	r.Use(httprate.LimitByIP(200, time.Minute))
@@ -36,17 +34,25 @@ func New(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) http.Handler {
 	r.Get("/healthz", handlers.Health())
 	r.Get("/api/healthz", handlers.Health())
 
-	// Repos & services
-	userRepo := postgres.NewUserRepo(db)
-	authSvc := service.NewAuthService(userRepo, cfg.SessionSecret)
-	authH := handlers.NewAuthHTTP(authSvc, userRepo)
+	// Handlers over the shared services (see NewServices)
+	authH := handlers.NewAuthHTTP(svc.Auth, svc.Users)
+	taxonomyH := handlers.NewTaxonomyHTTP(svc.Taxonomy)
+	calendarH := handlers.NewCalendarHTTP(svc.Calendars)
+	slaH := handlers.NewSLAHTTP(svc.SLA)
+	assignH := handlers.NewAssignmentHTTP(svc.Assignment)
+	skillH := handlers.NewSkillHTTP(svc.Skills)
+	ticketH := handlers.NewTicketHTTP(svc.Tickets, svc.Ticket)
+	aliasH := handlers.NewAliasFormatHTTP(svc.AliasFormats)
+	fieldH := handlers.NewCustomFieldHTTP(svc.CustomFields)
+	templateH := handlers.NewTicketTemplateHTTP(svc.TicketTemplates)
+	notifyH := handlers.NewNotificationHTTP(svc.Notifications)
+	watcherH := handlers.NewWatcherHTTP(svc.Watchers)
+	tagH := handlers.NewTagHTTP(svc.Tags)
+	teamH := handlers.NewTeamHTTP(svc.Teams)
+	attachH := handlers.NewAttachmentHTTP(svc.Attachments)
 
-	ticketRepo := postgres.NewTicketRepo(db)
-	// Pass userRepo into TicketHTTP for auto-assignment logic
-	ticketH := handlers.NewTicketHTTP(ticketRepo, userRepo)
-
-	// Reports (uses ticketRepo counters when available, else falls back)
-	reportsH := handlers.NewReportsHTTP(ticketRepo)
+	// Reports (SQL counters over the configurable taxonomy)
+	reportsH := handlers.NewReportsHTTP(svc.Tickets, svc.Taxonomy)
 
 	// Tickets (RBAC-enforced)
 	r.Route("/api/tickets", func(r chi.Router) {
@@ -56,7 +62,13 @@ func New(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) http.Handler {
 		// Create requires authentication
 		r.With(middleware.RequireAuth).Post("/", ticketH.Create())
 
+		// Bulk changes (staff; same validation as PATCH, per-ticket results)
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Post("/bulk", ticketH.Bulk())
+
 		r.Route("/{id}", func(r chi.Router) {
+			// {id} may be a UUID or an alias (case-insensitive)
+			r.Use(ticketH.ResolveID)
+
 			// Get single ticket
 			r.Get("/", ticketH.Get())
 
@@ -64,25 +76,177 @@ func New(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) http.Handler {
 			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
 				Patch("/", ticketH.Update())
 
+			// Claim/release (staff; claim is atomic, 409 if already taken)
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/claim", ticketH.Claim())
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/release", ticketH.Release())
+
+			// Merge a duplicate into another ticket (staff)
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/merge", ticketH.Merge())
+
+			// Links to other tickets (staff manage; embedded in GET)
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/links", ticketH.AddLink())
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Delete("/links/{linkId}", ticketH.RemoveLink())
+
+			// Tags (staff; unknown tags are created only if TAGS_AD_HOC is set)
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/tags", tagH.AddToTicket())
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Delete("/tags/{tag}", tagH.RemoveFromTicket())
+
+			// Watchers (anyone who can see the ticket follows/unfollows; staff add CCs)
+			r.With(middleware.RequireAuth).Get("/watchers", watcherH.List())
+			r.With(middleware.RequireAuth).Post("/watch", watcherH.Follow())
+			r.With(middleware.RequireAuth).Delete("/watch", watcherH.Unfollow())
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Post("/watchers", watcherH.Add())
+			r.With(middleware.RequireAuth).Delete("/watchers/{userId}", watcherH.Remove())
+
+			// Change history (same visibility as the ticket itself)
+			r.Get("/history", ticketH.History())
+
 			// Comments allowed for authenticated users
 			r.With(middleware.RequireAuth).
 				Post("/comments", ticketH.AddComment())
+
+			// Comment edit/delete (author within edit window, admin any time)
+			r.With(middleware.RequireAuth).
+				Patch("/comments/{commentId}", ticketH.UpdateComment())
+			r.With(middleware.RequireAuth).
+				Delete("/comments/{commentId}", ticketH.DeleteComment())
+			r.With(middleware.RequireRoles("admin", "agent", "supervisor")).
+				Get("/comments/{commentId}/revisions", ticketH.CommentRevisions())
+
+			// Attachments (same visibility as the ticket; uploads require auth)
+			r.Get("/attachments", attachH.List())
+			r.Get("/attachments/{attachmentId}", attachH.Download())
+			r.With(middleware.RequireAuth).Post("/attachments", attachH.Upload())
+			r.With(middleware.RequireAuth).
+				Post("/comments/{commentId}/attachments", attachH.Upload())
 		})
 	})
 
+	// SLA policies (staff can read, admins manage)
+	r.Route("/api/sla-policies", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/", slaH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", slaH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", slaH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", slaH.Delete())
+	})
+
+	// Business-hour calendars for SLA math (staff can read, admins manage)
+	r.Route("/api/calendars", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/", calendarH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", calendarH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", calendarH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", calendarH.Delete())
+	})
+
+	// Teams (staff can read, admins manage teams and membership)
+	r.Route("/api/teams", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/", teamH.List())
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/{id}", teamH.Get())
+		r.With(middleware.RequireRoles("admin")).Post("/", teamH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", teamH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", teamH.Delete())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}/members/{userId}", teamH.SetMember())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}/members/{userId}", teamH.RemoveMember())
+	})
+
+	// Team queues (membership is checked in service.TeamService)
+	r.Route("/api/queues/{id}", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/tickets", teamH.Queue())
+		r.With(middleware.RequireRoles("admin", "supervisor")).Post("/rebalance", teamH.Rebalance())
+	})
+
+	// Ticket taxonomy (any signed-in user can read, admins manage)
+	r.Route("/api/taxonomy", func(r chi.Router) {
+		r.With(middleware.RequireAuth).Get("/", taxonomyH.Get())
+		r.With(middleware.RequireRoles("admin")).Post("/statuses", taxonomyH.SaveStatus())
+		r.With(middleware.RequireRoles("admin")).Put("/statuses/{id}", taxonomyH.SaveStatus())
+		r.With(middleware.RequireRoles("admin")).Delete("/statuses/{id}", taxonomyH.DeleteStatus())
+		r.With(middleware.RequireRoles("admin")).Post("/priorities", taxonomyH.SavePriority())
+		r.With(middleware.RequireRoles("admin")).Put("/priorities/{id}", taxonomyH.SavePriority())
+		r.With(middleware.RequireRoles("admin")).Delete("/priorities/{id}", taxonomyH.DeletePriority())
+		r.With(middleware.RequireRoles("admin")).Post("/categories", taxonomyH.SaveCategory())
+		r.With(middleware.RequireRoles("admin")).Put("/categories/{id}", taxonomyH.SaveCategory())
+		r.With(middleware.RequireRoles("admin")).Delete("/categories/{id}", taxonomyH.DeleteCategory())
+	})
+
+	// Custom ticket fields (any signed-in user can read, admins manage)
+	r.Route("/api/custom-fields", func(r chi.Router) {
+		r.With(middleware.RequireAuth).Get("/", fieldH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", fieldH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", fieldH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", fieldH.Delete())
+	})
+
+	// Ticket templates / request forms (any signed-in user can read, admins manage)
+	r.Route("/api/ticket-templates", func(r chi.Router) {
+		r.With(middleware.RequireAuth).Get("/", templateH.List())
+		r.With(middleware.RequireAuth).Get("/{id}", templateH.Get())
+		r.With(middleware.RequireRoles("admin")).Post("/", templateH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", templateH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", templateH.Delete())
+	})
+
+	// In-app notifications (each user sees only their own)
+	r.Route("/api/notifications", func(r chi.Router) {
+		r.With(middleware.RequireAuth).Get("/", notifyH.List())
+		r.With(middleware.RequireAuth).Get("/unread-count", notifyH.UnreadCount())
+		r.With(middleware.RequireAuth).Post("/read-all", notifyH.MarkAllRead())
+		r.With(middleware.RequireAuth).Post("/{id}/read", notifyH.MarkRead())
+		r.With(middleware.RequireAuth).Get("/preferences", notifyH.Preferences())
+		r.With(middleware.RequireAuth).Put("/preferences", notifyH.SetPreferences())
+	})
+
+	// Tags (staff can read, admins manage)
+	r.Route("/api/tags", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/", tagH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", tagH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", tagH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", tagH.Delete())
+	})
+
+	// Alias formats per category/team (admins only)
+	r.Route("/api/alias-formats", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin")).Get("/", aliasH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", aliasH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", aliasH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", aliasH.Delete())
+	})
+
+	// Auto-assignment rules (admins only)
+	r.Route("/api/assignment-rules", func(r chi.Router) {
+		r.With(middleware.RequireRoles("admin")).Get("/", assignH.List())
+		r.With(middleware.RequireRoles("admin")).Post("/", assignH.Create())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}", assignH.Update())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}", assignH.Delete())
+	})
+
 	// Reports
 	r.Route("/api/reports", func(r chi.Router) {
 		r.Get("/summary", reportsH.Summary())
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/tags", tagH.Report())
 	})
 
 	// Users (admin-only listing & admin ops; self-service updates require auth)
-	userH := handlers.NewUserHTTP(userRepo)
+	userH := handlers.NewUserHTTP(svc.Users)
 	r.Route("/api/users", func(r chi.Router) {
 		// Admin-only endpoints
 		r.With(middleware.RequireRoles("admin")).Get("/", userH.List())
 		r.With(middleware.RequireRoles("admin")).Patch("/{id}/role", userH.UpdateRole())
 		r.With(middleware.RequireRoles("admin")).Patch("/{id}/active", userH.SetActive())
 
+		// Skill profiles for skill-based routing (staff can read, admins manage)
+		r.With(middleware.RequireRoles("admin", "agent", "supervisor")).Get("/{id}/skills", skillH.List())
+		r.With(middleware.RequireRoles("admin")).Put("/{id}/skills/{skill}", skillH.Set())
+		r.With(middleware.RequireRoles("admin")).Delete("/{id}/skills/{skill}", skillH.Delete())
+
 		// Self-service (any authenticated user can update own basic info/password)
 		r.With(middleware.RequireAuth).Patch("/{id}/basic", userH.UpdateBasic())
 		r.With(middleware.RequireAuth).Patch("/{id}/password", userH.UpdatePassword())
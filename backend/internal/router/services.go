@@ -0,0 +1,84 @@
+package router
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"gh-ts/internal/config"
+	"gh-ts/internal/repository"
+	"gh-ts/internal/repository/postgres"
+	"gh-ts/internal/service"
+	"gh-ts/internal/storage"
+)
+
+// Services is the application wiring, built once and shared by the HTTP
+// router and the background workers so both see the same instances.
+type Services struct {
+	Users   repository.UserRepository
+	Tickets *postgres.TicketRepo
+
+	Auth            *service.AuthService
+	Taxonomy        *service.TaxonomyService
+	Calendars       *service.CalendarService
+	SLA             *service.SLAService
+	Assignment      *service.AssignmentService
+	Skills          *service.SkillService
+	Ticket          *service.TicketService
+	AliasFormats    *service.AliasFormatService
+	CustomFields    *service.CustomFieldService
+	TicketTemplates *service.TicketTemplateService
+	Notifications   *service.NotificationService
+	Watchers        *service.WatcherService
+	Tags            *service.TagService
+	Teams           *service.TeamService
+	Attachments     *service.AttachmentService
+}
+
+func NewServices(log zerolog.Logger, db *pgxpool.Pool, cfg config.Config) (*Services, error) {
+	// Attachments (local filesystem storage; see storage.Storage for other backends)
+	store, err := storage.NewLocalStorage(cfg.UploadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Services{
+		Users:   postgres.NewUserRepo(db),
+		Tickets: postgres.NewTicketRepo(db),
+	}
+	s.Auth = service.NewAuthService(s.Users, cfg.SessionSecret)
+	// Admin-managed statuses/priorities/categories used for validation and reports
+	s.Taxonomy = service.NewTaxonomyService(postgres.NewTaxonomyRepo(db))
+	s.Calendars = service.NewCalendarService(postgres.NewCalendarRepo(db))
+	s.SLA = service.NewSLAService(postgres.NewSLAPolicyRepo(db), s.Calendars, s.Taxonomy)
+	s.Assignment = service.NewAssignmentService(postgres.NewAssignmentRepo(db), s.Users, s.Taxonomy)
+	s.Skills = service.NewSkillService(postgres.NewSkillRepo(db), s.Users)
+
+	// Ticket service owns validation, auto-assignment, SLA and the status lifecycle
+	teamRepo := postgres.NewTeamRepo(db)
+	fieldRepo := postgres.NewCustomFieldRepo(db)
+	templateRepo := postgres.NewTicketTemplateRepo(db)
+	watcherRepo := postgres.NewWatcherRepo(db)
+	s.Notifications = service.NewNotificationService(postgres.NewNotificationRepo(db), log)
+	s.Ticket = service.NewTicketService(service.TicketDeps{
+		Tickets:   s.Tickets,
+		Users:     s.Users,
+		Teams:     teamRepo,
+		Links:     postgres.NewTicketLinkRepo(db),
+		Fields:    fieldRepo,
+		Templates: templateRepo,
+		Watchers:  watcherRepo,
+		Notifier:  s.Notifications,
+		SLA:       s.SLA,
+		Assigner:  s.Assignment,
+		Taxonomy:  s.Taxonomy,
+	})
+
+	s.AliasFormats = service.NewAliasFormatService(postgres.NewAliasFormatRepo(db), teamRepo, s.Taxonomy)
+	s.CustomFields = service.NewCustomFieldService(fieldRepo, s.Taxonomy)
+	s.TicketTemplates = service.NewTicketTemplateService(templateRepo, fieldRepo, s.Taxonomy)
+	s.Watchers = service.NewWatcherService(watcherRepo, s.Tickets, s.Users)
+	s.Tags = service.NewTagService(postgres.NewTagRepo(db), s.Tickets, cfg.TagsAdHoc)
+	s.Teams = service.NewTeamService(teamRepo, s.Users, s.Tickets, s.Ticket)
+	s.Attachments = service.NewAttachmentService(postgres.NewAttachmentRepo(db), s.Tickets, store, cfg.UploadMaxBytes)
+	return s, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrAliasFormatNotFound = errors.New("alias format not found")
+
+// aliasPrefixRe allows e.g. "NET-", "HW", "ACC2-". Aliases are matched
+// case-insensitively, so prefixes are stored upper-case.
+var aliasPrefixRe = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,11}-?$`)
+
+// AliasFormatService manages per-category/per-team alias formats. Aliases
+// themselves are assigned by the ticket_alias_default() trigger.
+type AliasFormatService struct {
+	formats  repository.AliasFormatRepository
+	teams    repository.TeamRepository
+	taxonomy *TaxonomyService
+}
+
+func NewAliasFormatService(formats repository.AliasFormatRepository, teams repository.TeamRepository, taxonomy *TaxonomyService) *AliasFormatService {
+	return &AliasFormatService{formats: formats, teams: teams, taxonomy: taxonomy}
+}
+
+func (s *AliasFormatService) List(ctx context.Context) ([]models.AliasFormat, error) {
+	return s.formats.List(ctx)
+}
+
+func (s *AliasFormatService) Create(ctx context.Context, f *models.AliasFormat) error {
+	f.ID = ""
+	if err := s.validate(ctx, f); err != nil {
+		return err
+	}
+	return s.formats.Create(ctx, f)
+}
+
+func (s *AliasFormatService) Update(ctx context.Context, f *models.AliasFormat) error {
+	if _, err := uuid.Parse(f.ID); err != nil {
+		return ErrAliasFormatNotFound
+	}
+	existing, err := s.formats.Get(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAliasFormatNotFound
+	}
+	if err := s.validate(ctx, f); err != nil {
+		return err
+	}
+	if err := s.formats.Update(ctx, f); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAliasFormatNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a format. Tickets already numbered keep their aliases;
+// new tickets fall back to the default scheme.
+func (s *AliasFormatService) Delete(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrAliasFormatNotFound
+	}
+	existing, err := s.formats.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAliasFormatNotFound
+	}
+	if err := s.formats.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAliasFormatNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AliasFormatService) validate(ctx context.Context, f *models.AliasFormat) error {
+	f.Prefix = strings.ToUpper(strings.TrimSpace(f.Prefix))
+	f.Category = strings.TrimSpace(f.Category)
+	f.TeamID = strings.TrimSpace(f.TeamID)
+
+	if !aliasPrefixRe.MatchString(f.Prefix) {
+		return invalid("prefix must be 1-12 letters/digits starting with a letter, optionally ending in '-'")
+	}
+	if strings.TrimSuffix(f.Prefix, "-") == "TKT" {
+		return invalid("prefix TKT is reserved for the default format")
+	}
+	if (f.Category == "") == (f.TeamID == "") {
+		return invalid("exactly one of category or teamId is required")
+	}
+	if f.Category != "" {
+		if err := s.taxonomy.ValidateCategory(ctx, f.Category); err != nil {
+			return err
+		}
+	}
+	if f.TeamID != "" {
+		if _, err := uuid.Parse(f.TeamID); err != nil {
+			return invalid("invalid team id")
+		}
+		team, err := s.teams.Get(ctx, f.TeamID)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return invalid("team not found")
+		}
+	}
+	if f.Padding == 0 {
+		f.Padding = 5
+	}
+	if f.Padding < 1 || f.Padding > 10 {
+		return invalid("padding must be between 1 and 10")
+	}
+
+	conflict, err := s.formats.FindConflict(ctx, f)
+	if err != nil {
+		return err
+	}
+	if conflict != "" {
+		return invalid("another format already uses this prefix, category or team")
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrAssignmentRuleNotFound = errors.New("assignment rule not found")
+
+var allowedAssignStrategies = map[string]struct{}{
+	models.AssignRoundRobin:  {},
+	models.AssignLeastLoaded: {},
+	models.AssignUser:        {},
+	models.AssignSkills:      {},
+}
+
+// AssignmentService picks an assignee for new tickets. Active rules are
+// tried in position order; the first rule whose category/department match
+// and whose strategy yields an agent wins. Without a match the ticket goes
+// to the first active admin.
+type AssignmentService struct {
+	rules    repository.AssignmentRepository
+	users    repository.UserRepository
+	taxonomy *TaxonomyService
+}
+
+func NewAssignmentService(rules repository.AssignmentRepository, users repository.UserRepository, taxonomy *TaxonomyService) *AssignmentService {
+	return &AssignmentService{rules: rules, users: users, taxonomy: taxonomy}
+}
+
+// Assign returns the assignee for t, or ErrNoDefaultAssignee if neither a
+// rule nor the admin fallback produce one.
+func (s *AssignmentService) Assign(ctx context.Context, t *models.Ticket) (string, error) {
+	if s.rules != nil {
+		rules, err := s.rules.ListRules(ctx, true)
+		if err != nil {
+			return "", err
+		}
+		for _, r := range rules {
+			if !ruleMatches(r, t) {
+				continue
+			}
+			id, err := s.pick(ctx, r, t)
+			if err != nil {
+				return "", err
+			}
+			if id != "" {
+				return id, nil
+			}
+		}
+	}
+
+	adminID, err := s.users.FirstActiveAdminID(ctx)
+	if err != nil || strings.TrimSpace(adminID) == "" {
+		return "", ErrNoDefaultAssignee
+	}
+	return adminID, nil
+}
+
+func ruleMatches(r models.AssignmentRule, t *models.Ticket) bool {
+	if r.Category != "" && !strings.EqualFold(r.Category, t.Category) {
+		return false
+	}
+	if r.Department != "" && !strings.EqualFold(r.Department, t.Department) {
+		return false
+	}
+	return true
+}
+
+// pick runs the rule's strategy. An empty result means "no candidate", so
+// evaluation falls through to the next rule.
+func (s *AssignmentService) pick(ctx context.Context, r models.AssignmentRule, t *models.Ticket) (string, error) {
+	switch r.Strategy {
+	case models.AssignRoundRobin:
+		return s.rules.NextRoundRobin(ctx, r.ID)
+	case models.AssignLeastLoaded:
+		return s.rules.LeastLoadedAgent(ctx)
+	case models.AssignSkills:
+		if t.Category == "" {
+			return "", nil
+		}
+		return s.rules.BestSkilledAgent(ctx, t.Category)
+	case models.AssignUser:
+		if r.TargetUserID == "" {
+			return "", nil
+		}
+		u, err := s.users.GetByID(ctx, r.TargetUserID)
+		if err != nil {
+			return "", err
+		}
+		if u == nil || !u.Active {
+			return "", nil
+		}
+		return u.ID, nil
+	}
+	return "", nil
+}
+
+// -----------------------------------------------------------------------------
+// Rule administration
+// -----------------------------------------------------------------------------
+
+func (s *AssignmentService) ListRules(ctx context.Context) ([]models.AssignmentRule, error) {
+	return s.rules.ListRules(ctx, false)
+}
+
+func (s *AssignmentService) CreateRule(ctx context.Context, r *models.AssignmentRule) error {
+	if err := s.validateRule(ctx, r); err != nil {
+		return err
+	}
+	return s.rules.CreateRule(ctx, r)
+}
+
+func (s *AssignmentService) UpdateRule(ctx context.Context, r *models.AssignmentRule) error {
+	if _, err := uuid.Parse(r.ID); err != nil {
+		return ErrAssignmentRuleNotFound
+	}
+	existing, err := s.rules.GetRule(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAssignmentRuleNotFound
+	}
+	if err := s.validateRule(ctx, r); err != nil {
+		return err
+	}
+	if err := s.rules.UpdateRule(ctx, r); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAssignmentRuleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AssignmentService) DeleteRule(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrAssignmentRuleNotFound
+	}
+	existing, err := s.rules.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAssignmentRuleNotFound
+	}
+	if err := s.rules.DeleteRule(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAssignmentRuleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AssignmentService) validateRule(ctx context.Context, r *models.AssignmentRule) error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Category = strings.TrimSpace(r.Category)
+	r.Department = strings.TrimSpace(r.Department)
+	r.Strategy = strings.ToLower(strings.TrimSpace(r.Strategy))
+	r.TargetUserID = strings.TrimSpace(r.TargetUserID)
+	if r.Name == "" {
+		return invalid("name is required")
+	}
+	if _, ok := allowedAssignStrategies[r.Strategy]; !ok {
+		return invalid("invalid strategy")
+	}
+	if err := s.taxonomy.ValidateCategory(ctx, r.Category); err != nil {
+		return err
+	}
+	if r.Strategy != models.AssignUser {
+		r.TargetUserID = ""
+		return nil
+	}
+
+	if r.TargetUserID == "" {
+		return invalid("targetUserId is required for strategy user")
+	}
+	if _, err := uuid.Parse(r.TargetUserID); err != nil {
+		return invalid("invalid targetUserId")
+	}
+	u, err := s.users.GetByID(ctx, r.TargetUserID)
+	if err != nil {
+		return err
+	}
+	if u == nil || !u.Active {
+		return invalid("target user not found or inactive")
+	}
+	if _, ok := allowedAssigneeRoles[strings.ToLower(u.Role)]; !ok {
+		return invalid("target user role not permitted")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// fakeAssignmentRules serves rules from memory; round robin cycles through
+// agents per rule.
+type fakeAssignmentRules struct {
+	repository.AssignmentRepository
+	rules       []models.AssignmentRule
+	agents      []string
+	cursor      map[string]int
+	leastLoaded string
+	skilled     map[string]string // skill -> agent
+}
+
+func (f *fakeAssignmentRules) ListRules(_ context.Context, activeOnly bool) ([]models.AssignmentRule, error) {
+	var out []models.AssignmentRule
+	for _, r := range f.rules {
+		if r.Active || !activeOnly {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeAssignmentRules) NextRoundRobin(_ context.Context, ruleID string) (string, error) {
+	if len(f.agents) == 0 {
+		return "", nil
+	}
+	if f.cursor == nil {
+		f.cursor = map[string]int{}
+	}
+	id := f.agents[f.cursor[ruleID]%len(f.agents)]
+	f.cursor[ruleID]++
+	return id, nil
+}
+
+func (f *fakeAssignmentRules) LeastLoadedAgent(context.Context) (string, error) {
+	return f.leastLoaded, nil
+}
+
+func (f *fakeAssignmentRules) BestSkilledAgent(_ context.Context, skill string) (string, error) {
+	return f.skilled[skill], nil
+}
+
+func TestRuleMatches(t *testing.T) {
+	ticket := &models.Ticket{Category: "Network", Department: "IT"}
+	tests := []struct {
+		name string
+		rule models.AssignmentRule
+		want bool
+	}{
+		{"catch-all", models.AssignmentRule{}, true},
+		{"category", models.AssignmentRule{Category: "Network"}, true},
+		{"category ignores case", models.AssignmentRule{Category: "network"}, true},
+		{"other category", models.AssignmentRule{Category: "Hardware"}, false},
+		{"department", models.AssignmentRule{Department: "it"}, true},
+		{"other department", models.AssignmentRule{Department: "Sales"}, false},
+		{"category and department", models.AssignmentRule{Category: "Network", Department: "IT"}, true},
+		{"category but other department", models.AssignmentRule{Category: "Network", Department: "Sales"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ruleMatches(tt.rule, ticket); got != tt.want {
+				t.Fatalf("ruleMatches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	users := newFakeUsers(
+		models.User{ID: "agent-1", Role: "agent", Active: true},
+		models.User{ID: "agent-gone", Role: "agent", Active: false},
+	)
+	rules := &fakeAssignmentRules{
+		agents:      []string{"agent-1", "agent-2"},
+		leastLoaded: "agent-3",
+		skilled:     map[string]string{"Network": "agent-4"},
+	}
+	s := NewAssignmentService(rules, users, nil)
+
+	tests := []struct {
+		name   string
+		rule   models.AssignmentRule
+		ticket models.Ticket
+		want   string
+	}{
+		{"least loaded", models.AssignmentRule{Strategy: models.AssignLeastLoaded}, models.Ticket{}, "agent-3"},
+		{"skills by category", models.AssignmentRule{Strategy: models.AssignSkills}, models.Ticket{Category: "Network"}, "agent-4"},
+		{"nobody has the skill", models.AssignmentRule{Strategy: models.AssignSkills}, models.Ticket{Category: "Hardware"}, ""},
+		{"skills without category", models.AssignmentRule{Strategy: models.AssignSkills}, models.Ticket{}, ""},
+		{"fixed user", models.AssignmentRule{Strategy: models.AssignUser, TargetUserID: "agent-1"}, models.Ticket{}, "agent-1"},
+		{"fixed user inactive", models.AssignmentRule{Strategy: models.AssignUser, TargetUserID: "agent-gone"}, models.Ticket{}, ""},
+		{"fixed user deleted", models.AssignmentRule{Strategy: models.AssignUser, TargetUserID: "agent-9"}, models.Ticket{}, ""},
+		{"fixed user unset", models.AssignmentRule{Strategy: models.AssignUser}, models.Ticket{}, ""},
+		{"unknown strategy", models.AssignmentRule{Strategy: "lottery"}, models.Ticket{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.pick(context.Background(), tt.rule, &tt.ticket)
+			if err != nil {
+				t.Fatalf("pick: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("pick = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("round robin rotates per rule", func(t *testing.T) {
+		var got []string
+		for _, id := range []string{"r1", "r1", "r2", "r1"} {
+			a, err := s.pick(context.Background(), models.AssignmentRule{ID: id, Strategy: models.AssignRoundRobin}, &models.Ticket{})
+			if err != nil {
+				t.Fatalf("pick: %v", err)
+			}
+			got = append(got, a)
+		}
+		want := []string{"agent-1", "agent-2", "agent-1", "agent-1"}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("round robin = %v, want %v", got, want)
+			}
+		}
+	})
+}
+
+func TestAssign(t *testing.T) {
+	users := newFakeUsers(
+		models.User{ID: "admin-1", Role: "admin", Active: true},
+		models.User{ID: "agent-gone", Role: "agent", Active: false},
+	)
+	rules := &fakeAssignmentRules{
+		rules: []models.AssignmentRule{
+			{ID: "inactive", Strategy: models.AssignLeastLoaded, Active: false},
+			{ID: "hardware", Category: "Hardware", Strategy: models.AssignLeastLoaded, Active: true},
+			{ID: "vacation", Category: "Network", Strategy: models.AssignUser, TargetUserID: "agent-gone", Active: true},
+			{ID: "network", Category: "Network", Strategy: models.AssignSkills, Active: true},
+		},
+		leastLoaded: "agent-3",
+		skilled:     map[string]string{"Network": "agent-4"},
+	}
+	s := NewAssignmentService(rules, users, nil)
+
+	tests := []struct {
+		name     string
+		category string
+		want     string
+	}{
+		{"first matching rule wins", "Hardware", "agent-3"},
+		{"falls through rules without a candidate", "Network", "agent-4"},
+		{"falls back to the first admin", "Access", "admin-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Assign(context.Background(), &models.Ticket{Category: tt.category})
+			if err != nil {
+				t.Fatalf("Assign: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Assign = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
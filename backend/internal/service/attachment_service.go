@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+	"gh-ts/internal/storage"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	ErrAttachmentType     = errors.New("attachment type not allowed")
+)
+
+// allowedAttachmentTypes lists media types accepted after content sniffing.
+// Office documents (docx/xlsx) sniff as application/zip.
+var allowedAttachmentTypes = map[string]struct{}{
+	"image/png":       {},
+	"image/jpeg":      {},
+	"image/gif":       {},
+	"image/webp":      {},
+	"application/pdf": {},
+	"application/zip": {},
+	"text/plain":      {},
+}
+
+// AttachmentService stores and serves ticket/comment attachments with the
+// same visibility rules as the tickets they belong to.
+type AttachmentService struct {
+	repo     repository.AttachmentRepository
+	tickets  repository.TicketRepository
+	store    storage.Storage
+	maxBytes int64
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository, tickets repository.TicketRepository, store storage.Storage, maxBytes int64) *AttachmentService {
+	return &AttachmentService{repo: repo, tickets: tickets, store: store, maxBytes: maxBytes}
+}
+
+// MaxBytes is the per-file size limit.
+func (s *AttachmentService) MaxBytes() int64 { return s.maxBytes }
+
+// Upload stores r as an attachment of ticketID (or of commentID when set).
+// The content type is sniffed from the data, never taken from the client.
+func (s *AttachmentService) Upload(ctx context.Context, actor Actor, ticketID, commentID, filename string, r io.Reader) (*models.Attachment, error) {
+	t, err := s.visibleTicket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if commentID != "" {
+		if _, err := uuid.Parse(commentID); err != nil {
+			return nil, ErrCommentNotFound
+		}
+		c, err := s.tickets.GetComment(ctx, t.ID, commentID)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil || (c.Internal && !IsStaff(actor.Role)) {
+			return nil, ErrCommentNotFound
+		}
+		if !IsStaff(actor.Role) && c.AuthorID != actor.ID {
+			return nil, ErrForbidden
+		}
+	}
+
+	filename = strings.TrimSpace(filepath.Base(filepath.Clean("/" + filename)))
+	if filename == "" || filename == "/" || filename == "." {
+		return nil, invalid("file name is required")
+	}
+	if len(filename) > 255 {
+		filename = filename[len(filename)-255:]
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return nil, invalid("file is empty")
+		}
+		return nil, err
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if _, ok := allowedAttachmentTypes[contentType]; !ok {
+		return nil, ErrAttachmentType
+	}
+
+	a := &models.Attachment{
+		ID:          uuid.NewString(),
+		TicketID:    t.ID,
+		CommentID:   commentID,
+		UploadedBy:  actor.ID,
+		Filename:    filename,
+		ContentType: contentType,
+	}
+	a.StorageKey = a.TicketID + "/" + a.ID
+
+	// Read one byte past the limit so oversize files are detected.
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), r), s.maxBytes+1)
+	size, err := s.store.Put(ctx, a.StorageKey, body)
+	if err == nil && size > s.maxBytes {
+		err = ErrAttachmentTooLarge
+	}
+	if err != nil {
+		_ = s.store.Delete(ctx, a.StorageKey)
+		return nil, err
+	}
+	a.SizeBytes = size
+
+	if err := s.repo.Create(ctx, a); err != nil {
+		_ = s.store.Delete(ctx, a.StorageKey)
+		return nil, err
+	}
+	return a, nil
+}
+
+// List returns the attachments of a ticket visible to actor.
+func (s *AttachmentService) List(ctx context.Context, actor Actor, ticketID string) ([]models.Attachment, error) {
+	t, err := s.visibleTicket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListByTicket(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if IsStaff(actor.Role) {
+		return items, nil
+	}
+	out := make([]models.Attachment, 0, len(items))
+	for _, a := range items {
+		if !a.Internal {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// Open returns attachment metadata and a reader for its contents.
+// The caller must close the reader.
+func (s *AttachmentService) Open(ctx context.Context, actor Actor, ticketID, id string) (*models.Attachment, io.ReadCloser, error) {
+	t, err := s.visibleTicket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	a, err := s.repo.Get(ctx, t.ID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil || (a.Internal && !IsStaff(actor.Role)) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	rc, err := s.store.Open(ctx, a.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+// CleanupOrphans deletes stored objects older than grace that no attachment
+// row references (e.g. uploads interrupted between storage and DB insert).
+// It returns the number of objects removed.
+func (s *AttachmentService) CleanupOrphans(ctx context.Context, grace time.Duration) (int, error) {
+	cutoff := time.Now().Add(-grace)
+	removed := 0
+	err := s.store.Walk(ctx, func(key string, modTime time.Time) error {
+		if modTime.After(cutoff) {
+			return nil
+		}
+		ok, err := s.repo.KeyExists(ctx, key)
+		if err != nil || ok {
+			return err
+		}
+		if err := s.store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+func (s *AttachmentService) visibleTicket(ctx context.Context, actor Actor, ticketID string) (*models.Ticket, error) {
+	t, err := s.tickets.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	if !CanViewTicket(actor, t) {
+		return nil, ErrForbidden
+	}
+	return t, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// BulkLimit caps how many tickets one bulk request may touch.
+const BulkLimit = 200
+
+// BulkRequest selects tickets by IDs or, if none are given, by Filter and
+// applies Changes to each. Title/description are not bulk-editable.
+type BulkRequest struct {
+	IDs     []string
+	Filter  *repository.TicketFilter
+	Changes TicketPatch
+
+	// Atomic aborts the whole request if any ticket fails validation;
+	// otherwise failing tickets are skipped and the rest applied.
+	Atomic bool
+}
+
+// ErrBulkAborted marks tickets left untouched because an atomic bulk
+// request had failures elsewhere.
+var ErrBulkAborted = errors.New("not applied: other tickets failed")
+
+// BulkResult is the outcome for one ticket; Err is nil on success.
+type BulkResult struct {
+	ID  string
+	Err error
+}
+
+// Bulk validates the changes against every selected ticket exactly like
+// Update, then writes all tickets that passed in a single transaction (with
+// per-ticket history). Results are reported per ticket in request order.
+func (s *TicketService) Bulk(ctx context.Context, actor Actor, req BulkRequest) ([]BulkResult, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	p := req.Changes
+	p.Title, p.Description, p.CustomFields, p.Version = nil, nil, nil, 0
+	if !p.changes() {
+		return nil, invalid("no changes given")
+	}
+
+	ids, err := s.bulkSelect(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkResult, len(ids))
+	var updates []repository.TicketUpdate
+	var befores []models.Ticket // each update's ticket before the change
+	var pending []int           // results index of each update
+	queued := map[string]bool{} // resolved ticket IDs, so an alias and its UUID count once
+	for i, id := range ids {
+		results[i].ID = id
+		var t *models.Ticket
+		// IDs may be UUIDs or aliases, as on /api/tickets/{id}
+		if id, err = s.ResolveID(ctx, id); err == nil {
+			if queued[id] {
+				err = invalid("ticket selected more than once")
+			} else if t, err = s.tickets.Get(ctx, id); err == nil && t == nil {
+				err = ErrTicketNotFound
+			}
+		}
+		var events []models.TicketEvent
+		var before models.Ticket
+		if err == nil {
+			before = *t
+			events, err = s.applyPatch(ctx, actor, t, p)
+		}
+		if err != nil {
+			if !isBulkItemError(err) {
+				return nil, err
+			}
+			results[i].Err = err
+			continue
+		}
+		queued[id] = true
+		updates = append(updates, repository.TicketUpdate{Ticket: t, Events: events})
+		befores = append(befores, before)
+		pending = append(pending, i)
+	}
+
+	if req.Atomic && len(pending) != len(ids) {
+		for _, i := range pending {
+			results[i].Err = ErrBulkAborted
+		}
+		return results, nil
+	}
+
+	if len(updates) > 0 {
+		if err := s.tickets.UpdateBatch(ctx, updates); err != nil {
+			if !isBulkItemError(err) {
+				return nil, err
+			}
+			// The transaction rolled back: nothing was applied
+			for _, i := range pending {
+				results[i].Err = err
+			}
+			return results, nil
+		}
+	}
+	for k, u := range updates {
+		s.notifyChanges(ctx, actor.ID, &befores[k], u.Ticket)
+	}
+	return results, nil
+}
+
+// bulkSelect resolves the target ticket IDs (deduplicated, capped).
+func (s *TicketService) bulkSelect(ctx context.Context, req BulkRequest) ([]string, error) {
+	var ids []string
+	if len(req.IDs) > 0 {
+		seen := map[string]bool{}
+		for _, id := range req.IDs {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	} else if req.Filter != nil {
+		f := *req.Filter
+		n, err := s.tickets.CountAdv(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		if n > BulkLimit {
+			return nil, invalid(fmt.Sprintf("filter matches %d tickets (max %d)", n, BulkLimit))
+		}
+		f.Limit, f.Offset = BulkLimit, 0
+		tickets, err := s.tickets.ListAdv(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tickets {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, invalid("no tickets selected")
+	}
+	if len(ids) > BulkLimit {
+		return nil, invalid(fmt.Sprintf("too many tickets selected (max %d)", BulkLimit))
+	}
+	return ids, nil
+}
+
+// isBulkItemError reports whether err concerns a single ticket (reported in
+// its result) rather than the request as a whole.
+func isBulkItemError(err error) bool {
+	var ve *ValidationError
+	return errors.As(err, &ve) ||
+		errors.Is(err, ErrTicketNotFound) ||
+		errors.Is(err, ErrForbidden) ||
+		errors.Is(err, ErrInvalidTransition) ||
+		errors.Is(err, ErrVersionConflict)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+const (
+	openTicketID    = "3d9a6f0e-8b1c-4c2e-a7d5-1f0e9b8c7a01"
+	newTicketID     = "3d9a6f0e-8b1c-4c2e-a7d5-1f0e9b8c7a02"
+	missingTicketID = "3d9a6f0e-8b1c-4c2e-a7d5-1f0e9b8c7aff"
+)
+
+// newBulkTickets returns an Open ticket, which may be resolved, and a New
+// one, which may not.
+func newBulkTickets() *fakeTickets {
+	return newFakeTickets(
+		models.Ticket{ID: openTicketID, Alias: "TKT-2026-00001", Status: "Open", Priority: "Low"},
+		models.Ticket{ID: newTicketID, Alias: "TKT-2026-00002", Status: StatusNew, Priority: "Low"},
+	)
+}
+
+func bulkResolve(ids []string, atomic bool) BulkRequest {
+	resolved := StatusResolved
+	return BulkRequest{IDs: ids, Changes: TicketPatch{Status: &resolved}, Atomic: atomic}
+}
+
+func TestBulk(t *testing.T) {
+	agent := Actor{ID: "a1", Role: "agent"}
+	tests := []struct {
+		name     string
+		req      BulkRequest
+		want     []error // per result, in request order
+		resolved []string
+	}{
+		{
+			name:     "all valid",
+			req:      bulkResolve([]string{openTicketID}, true),
+			want:     []error{nil},
+			resolved: []string{openTicketID},
+		},
+		{
+			name:     "partial applies the valid tickets",
+			req:      bulkResolve([]string{openTicketID, newTicketID, missingTicketID}, false),
+			want:     []error{nil, ErrInvalidTransition, ErrTicketNotFound},
+			resolved: []string{openTicketID},
+		},
+		{
+			name: "atomic applies nothing when one ticket fails",
+			req:  bulkResolve([]string{openTicketID, newTicketID}, true),
+			want: []error{ErrBulkAborted, ErrInvalidTransition},
+		},
+		{
+			name:     "aliases resolve, case-insensitively",
+			req:      bulkResolve([]string{"tkt-2026-00001", "TKT-2026-09999"}, false),
+			want:     []error{nil, ErrTicketNotFound},
+			resolved: []string{openTicketID},
+		},
+		{
+			name:     "an alias and its UUID count once",
+			req:      bulkResolve([]string{openTicketID, "TKT-2026-00001"}, false),
+			want:     []error{nil, &ValidationError{Msg: "ticket selected more than once"}},
+			resolved: []string{openTicketID},
+		},
+		{
+			name:     "repeated ids are dropped",
+			req:      bulkResolve([]string{openTicketID, " " + openTicketID + " ", ""}, true),
+			want:     []error{nil},
+			resolved: []string{openTicketID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := newBulkTickets()
+			s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+			results, err := s.Bulk(context.Background(), agent, tt.req)
+			if err != nil {
+				t.Fatalf("Bulk: %v", err)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.want))
+			}
+			for i, r := range results {
+				var ve *ValidationError
+				switch want := tt.want[i]; {
+				case errors.As(want, &ve):
+					wantErr(t, r.Err, ve.Msg)
+				case !errors.Is(r.Err, want):
+					t.Errorf("result %d (%s) = %v, want %v", i, r.ID, r.Err, want)
+				}
+			}
+			for _, id := range []string{openTicketID, newTicketID} {
+				got, _ := tickets.Get(context.Background(), id)
+				resolved := got.Status == StatusResolved
+				want := false
+				for _, r := range tt.resolved {
+					want = want || r == id
+				}
+				if resolved != want {
+					t.Errorf("ticket %s status = %s, resolved want %v", id, got.Status, want)
+				}
+			}
+		})
+	}
+}
+
+// A ticket that changes between validation and the batch write fails the
+// whole transaction; nothing is applied.
+func TestBulkVersionConflict(t *testing.T) {
+	tickets := newBulkTickets()
+	s := NewTicketService(TicketDeps{Tickets: &racingTickets{fakeTickets: tickets, bump: newTicketID}, Users: newFakeUsers()})
+	high := "High"
+	results, err := s.Bulk(context.Background(), Actor{ID: "a1", Role: "agent"}, BulkRequest{
+		IDs:     []string{openTicketID, newTicketID},
+		Changes: TicketPatch{Priority: &high},
+	})
+	if err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, ErrVersionConflict) {
+			t.Errorf("result %s = %v, want ErrVersionConflict", r.ID, r.Err)
+		}
+	}
+	if got, _ := tickets.Get(context.Background(), openTicketID); got.Priority != "Low" {
+		t.Fatalf("priority = %s, want the batch rolled back", got.Priority)
+	}
+}
+
+// racingTickets bumps the version of ticket bump right before a batch write,
+// as a concurrent editor would.
+type racingTickets struct {
+	*fakeTickets
+	bump string
+}
+
+func (r *racingTickets) UpdateBatch(ctx context.Context, updates []repository.TicketUpdate) error {
+	r.mu.Lock()
+	r.byID[r.bump].Version++
+	r.mu.Unlock()
+	return r.fakeTickets.UpdateBatch(ctx, updates)
+}
+
+func TestBulkRequest(t *testing.T) {
+	s := NewTicketService(TicketDeps{Tickets: newBulkTickets(), Users: newFakeUsers()})
+	ctx := context.Background()
+	if _, err := s.Bulk(ctx, Actor{ID: "u1", Role: "end_user"}, bulkResolve([]string{openTicketID}, false)); !errors.Is(err, ErrForbidden) {
+		t.Errorf("end user: %v, want ErrForbidden", err)
+	}
+	title := "renamed"
+	_, err := s.Bulk(ctx, Actor{ID: "a1", Role: "agent"}, BulkRequest{IDs: []string{openTicketID}, Changes: TicketPatch{Title: &title}})
+	wantErr(t, err, "no changes given")
+	_, err = s.Bulk(ctx, Actor{ID: "a1", Role: "agent"}, bulkResolve([]string{" "}, false))
+	wantErr(t, err, "no tickets selected")
+}
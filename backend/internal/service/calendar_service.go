@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/calendar"
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrCalendarNotFound = errors.New("calendar not found")
+
+// weekdayKeys maps the JSON keys of BusinessCalendar.Hours to weekdays.
+var weekdayKeys = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// CalendarService manages business calendars and resolves the calendar that
+// applies to a ticket for SLA math.
+type CalendarService struct {
+	repo repository.CalendarRepository
+}
+
+func NewCalendarService(repo repository.CalendarRepository) *CalendarService {
+	return &CalendarService{repo: repo}
+}
+
+// For returns the working-time calendar for a department (falling back to
+// the default calendar). A nil calendar means 24x7.
+func (s *CalendarService) For(ctx context.Context, department string) (*calendar.Calendar, error) {
+	if s == nil {
+		return nil, nil
+	}
+	m, err := s.repo.ForDepartment(ctx, strings.TrimSpace(department))
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return BuildCalendar(m)
+}
+
+// BuildCalendar converts a stored calendar definition into a calendar.Calendar.
+func BuildCalendar(m *models.BusinessCalendar) (*calendar.Calendar, error) {
+	loc, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", m.Timezone)
+	}
+	week := make(map[time.Weekday][]calendar.Window, len(m.Hours))
+	for key, ranges := range m.Hours {
+		day, ok := weekdayKeys[strings.ToLower(key)]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", key)
+		}
+		for _, r := range ranges {
+			start, err := calendar.ParseClock(r.Start)
+			if err != nil {
+				return nil, err
+			}
+			end, err := calendar.ParseClock(r.End)
+			if err != nil {
+				return nil, err
+			}
+			week[day] = append(week[day], calendar.Window{Start: start, End: end})
+		}
+	}
+	days := make([]string, 0, len(m.Holidays))
+	for _, h := range m.Holidays {
+		days = append(days, h.Date)
+	}
+	return calendar.New(loc, week, days)
+}
+
+// -----------------------------------------------------------------------------
+// Administration
+// -----------------------------------------------------------------------------
+
+func (s *CalendarService) List(ctx context.Context) ([]models.BusinessCalendar, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *CalendarService) Create(ctx context.Context, c *models.BusinessCalendar) error {
+	if err := validateCalendar(c); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, c)
+}
+
+func (s *CalendarService) Update(ctx context.Context, c *models.BusinessCalendar) error {
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return ErrCalendarNotFound
+	}
+	existing, err := s.repo.Get(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrCalendarNotFound
+	}
+	if err := validateCalendar(c); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, c); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCalendarNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CalendarService) Delete(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrCalendarNotFound
+	}
+	existing, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrCalendarNotFound
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCalendarNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func validateCalendar(c *models.BusinessCalendar) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Timezone = strings.TrimSpace(c.Timezone)
+	c.Department = strings.TrimSpace(c.Department)
+	if c.Name == "" {
+		return invalid("name is required")
+	}
+	if c.Timezone == "" {
+		c.Timezone = "UTC"
+	}
+	if c.Holidays == nil {
+		c.Holidays = []models.Holiday{}
+	}
+	if _, err := BuildCalendar(c); err != nil {
+		return invalid(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	_ "time/tzdata"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// fakeCalendars keeps calendars in memory, keyed by id.
+type fakeCalendars struct {
+	repository.CalendarRepository
+	byID map[string]*models.BusinessCalendar
+}
+
+func (f *fakeCalendars) Get(_ context.Context, id string) (*models.BusinessCalendar, error) {
+	return f.byID[id], nil
+}
+
+func (f *fakeCalendars) Delete(_ context.Context, id string) error {
+	if f.byID[id] == nil {
+		return repository.ErrNotFound
+	}
+	delete(f.byID, id)
+	return nil
+}
+
+func TestValidateCalendar(t *testing.T) {
+	office := []models.TimeRange{{Start: "09:00", End: "17:00"}}
+	valid := func(mod func(c *models.BusinessCalendar)) *models.BusinessCalendar {
+		c := &models.BusinessCalendar{Name: "Office", Timezone: "Europe/Berlin", Hours: map[string][]models.TimeRange{"mon": office}}
+		if mod != nil {
+			mod(c)
+		}
+		return c
+	}
+	tests := []struct {
+		name string
+		c    *models.BusinessCalendar
+		want string
+	}{
+		{"valid", valid(nil), ""},
+		{"blank name", valid(func(c *models.BusinessCalendar) { c.Name = " " }), "name is required"},
+		{"unknown timezone", valid(func(c *models.BusinessCalendar) { c.Timezone = "Mars/Olympus" }), `invalid timezone "Mars/Olympus"`},
+		{"unknown weekday", valid(func(c *models.BusinessCalendar) { c.Hours["funday"] = office }), `invalid weekday "funday"`},
+		{"weekday keys are case-insensitive", valid(func(c *models.BusinessCalendar) { c.Hours = map[string][]models.TimeRange{"MON": office} }), ""},
+		{"malformed time", valid(func(c *models.BusinessCalendar) { c.Hours["tue"] = []models.TimeRange{{Start: "9am", End: "17:00"}} }), `invalid time "9am"`},
+		{"time past midnight", valid(func(c *models.BusinessCalendar) { c.Hours["tue"] = []models.TimeRange{{Start: "22:00", End: "24:30"}} }), `invalid time "24:30"`},
+		{"window ending at 24:00", valid(func(c *models.BusinessCalendar) { c.Hours["tue"] = []models.TimeRange{{Start: "22:00", End: "24:00"}} }), ""},
+		{"empty window", valid(func(c *models.BusinessCalendar) { c.Hours["tue"] = []models.TimeRange{{Start: "10:00", End: "10:00"}} }), "invalid hours on Tuesday"},
+		{"overlapping windows", valid(func(c *models.BusinessCalendar) {
+			c.Hours["tue"] = []models.TimeRange{{Start: "12:00", End: "18:00"}, {Start: "08:00", End: "12:30"}}
+		}), "overlapping hours on Tuesday"},
+		{"no business hours", valid(func(c *models.BusinessCalendar) { c.Hours = nil }), "calendar has no business hours"},
+		{"bad holiday", valid(func(c *models.BusinessCalendar) { c.Holidays = []models.Holiday{{Date: "2026-02-30"}} }), `invalid holiday date "2026-02-30"`},
+		{"holiday", valid(func(c *models.BusinessCalendar) {
+			c.Holidays = []models.Holiday{{Date: "2026-12-25", Name: "Christmas"}}
+		}), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantErr(t, validateCalendar(tt.c), tt.want)
+		})
+	}
+}
+
+func TestValidateCalendarDefaults(t *testing.T) {
+	c := &models.BusinessCalendar{
+		Name:       "  Support  ",
+		Department: " IT ",
+		Hours:      map[string][]models.TimeRange{"mon": {{Start: "00:00", End: "24:00"}}},
+	}
+	if err := validateCalendar(c); err != nil {
+		t.Fatalf("validateCalendar: %v", err)
+	}
+	if c.Name != "Support" || c.Department != "IT" || c.Timezone != "UTC" || c.Holidays == nil {
+		t.Fatalf("not normalized: %+v", c)
+	}
+}
+
+func TestCalendarNotFound(t *testing.T) {
+	ctx := context.Background()
+	const id = "0b7e2f8a-5c1d-4e3f-8a9b-6c2d1e0f3a41"
+	s := NewCalendarService(&fakeCalendars{byID: map[string]*models.BusinessCalendar{id: {ID: id}}})
+	for _, bad := range []string{"", "not-a-uuid", "0b7e2f8a-5c1d-4e3f-8a9b-6c2d1e0f3aff"} {
+		if err := s.Update(ctx, &models.BusinessCalendar{ID: bad}); !errors.Is(err, ErrCalendarNotFound) {
+			t.Errorf("Update(%q) = %v, want ErrCalendarNotFound", bad, err)
+		}
+		if err := s.Delete(ctx, bad); !errors.Is(err, ErrCalendarNotFound) {
+			t.Errorf("Delete(%q) = %v, want ErrCalendarNotFound", bad, err)
+		}
+	}
+	if err := s.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+}
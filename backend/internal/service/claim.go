@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"gh-ts/internal/models"
+)
+
+// Claim makes actor the assignee of an unassigned open ticket that is in no
+// team queue or in a queue of one of actor's teams. The check-and-set runs
+// as one statement, so of two concurrent claims exactly one wins; the other
+// gets ErrAlreadyClaimed.
+func (s *TicketService) Claim(ctx context.Context, actor Actor, id string) (*models.Ticket, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	terminal, err := s.taxonomy.IsTerminal(ctx, t.Status)
+	if err != nil {
+		return nil, err
+	}
+	if terminal {
+		return nil, invalid("ticket is " + t.Status)
+	}
+	if t.Assignee != "" {
+		return nil, ErrAlreadyClaimed
+	}
+	if t.TeamID != "" && s.teams != nil {
+		member, _, err := s.teams.Membership(ctx, t.TeamID, actor.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, fmt.Errorf("%w: ticket is in another team's queue", ErrForbidden)
+		}
+	}
+
+	ok, err := s.tickets.ClaimTicket(ctx, t.ID, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Lost the race (or the ticket changed since we read it)
+		return nil, ErrAlreadyClaimed
+	}
+	claimed, err := s.tickets.Get(ctx, t.ID)
+	if err != nil || claimed == nil {
+		return claimed, err
+	}
+	s.notifyChanges(ctx, actor.ID, t, claimed)
+	return claimed, nil
+}
+
+// Release clears the assignee so the ticket goes back to its queue. The
+// assignee may release their own ticket; admins any ticket; supervisors
+// tickets in their teams' queues.
+func (s *TicketService) Release(ctx context.Context, actor Actor, id string) (*models.Ticket, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	if t.Assignee == "" {
+		return nil, invalid("ticket is not assigned")
+	}
+
+	// expect pins the release to the assignee we authorised against
+	expect := t.Assignee
+	if t.Assignee != actor.ID {
+		allowed := actor.Role == "admin"
+		if !allowed && actor.Role == "supervisor" && t.TeamID != "" && s.teams != nil {
+			member, _, err := s.teams.Membership(ctx, t.TeamID, actor.ID)
+			if err != nil {
+				return nil, err
+			}
+			allowed = member
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: ticket is assigned to someone else", ErrForbidden)
+		}
+	}
+
+	ok, err := s.tickets.ReleaseTicket(ctx, t.ID, expect, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAlreadyClaimed
+	}
+	released, err := s.tickets.Get(ctx, t.ID)
+	if err != nil || released == nil {
+		return released, err
+	}
+	s.notifyChanges(ctx, actor.ID, t, released)
+	return released, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+func TestClaimRace(t *testing.T) {
+	const agents = 20
+	tickets := newFakeTickets(models.Ticket{ID: "t1", Status: "Open"})
+	s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners []string
+	)
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := s.Claim(context.Background(), Actor{ID: id, Role: "agent"}, "t1")
+			switch {
+			case err == nil:
+				mu.Lock()
+				winners = append(winners, id)
+				mu.Unlock()
+			case !errors.Is(err, ErrAlreadyClaimed):
+				t.Errorf("Claim(%s): %v", id, err)
+			}
+		}(fmt.Sprintf("agent-%d", i))
+	}
+	wg.Wait()
+
+	if len(winners) != 1 {
+		t.Fatalf("%d claims succeeded (%v), want exactly 1", len(winners), winners)
+	}
+	if got, _ := tickets.Get(context.Background(), "t1"); got.Assignee != winners[0] {
+		t.Fatalf("assignee = %q, want winner %q", got.Assignee, winners[0])
+	}
+}
+
+func TestClaim(t *testing.T) {
+	tests := []struct {
+		name   string
+		ticket models.Ticket
+		actor  Actor
+		want   error
+		msg    string
+	}{
+		{"unassigned", models.Ticket{Status: "Open"}, Actor{ID: "a1", Role: "agent"}, nil, ""},
+		{"already assigned", models.Ticket{Status: "Open", Assignee: "a2"}, Actor{ID: "a1", Role: "agent"}, ErrAlreadyClaimed, ""},
+		{"closed", models.Ticket{Status: StatusClosed}, Actor{ID: "a1", Role: "agent"}, nil, "ticket is Closed"},
+		{"end user", models.Ticket{Status: "Open"}, Actor{ID: "u1", Role: "end_user"}, ErrForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ticket.ID = "t1"
+			s := NewTicketService(TicketDeps{Tickets: newFakeTickets(tt.ticket), Users: newFakeUsers()})
+			got, err := s.Claim(context.Background(), tt.actor, "t1")
+			if tt.msg != "" {
+				wantErr(t, err, tt.msg)
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Claim = %v, want %v", err, tt.want)
+			}
+			if err == nil && got.Assignee != tt.actor.ID {
+				t.Fatalf("assignee = %q, want %q", got.Assignee, tt.actor.ID)
+			}
+		})
+	}
+	t.Run("missing ticket", func(t *testing.T) {
+		s := NewTicketService(TicketDeps{Tickets: newFakeTickets(), Users: newFakeUsers()})
+		if _, err := s.Claim(context.Background(), Actor{ID: "a1", Role: "agent"}, "t1"); !errors.Is(err, ErrTicketNotFound) {
+			t.Fatalf("Claim = %v, want ErrTicketNotFound", err)
+		}
+	})
+}
+
+func TestRelease(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor Actor
+		want  error
+	}{
+		{"assignee", Actor{ID: "a1", Role: "agent"}, nil},
+		{"admin", Actor{ID: "boss", Role: "admin"}, nil},
+		{"other agent", Actor{ID: "a2", Role: "agent"}, ErrForbidden},
+		{"supervisor outside the team", Actor{ID: "sup", Role: "supervisor"}, ErrForbidden},
+		{"end user", Actor{ID: "u1", Role: "end_user"}, ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := newFakeTickets(models.Ticket{ID: "t1", Status: "Open", Assignee: "a1", CreatedBy: "u1"})
+			s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+			got, err := s.Release(context.Background(), tt.actor, "t1")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Release = %v, want %v", err, tt.want)
+			}
+			if err == nil && got.Assignee != "" {
+				t.Fatalf("assignee = %q after release", got.Assignee)
+			}
+		})
+	}
+	t.Run("not assigned", func(t *testing.T) {
+		s := NewTicketService(TicketDeps{Tickets: newFakeTickets(models.Ticket{ID: "t1", Status: "Open"}), Users: newFakeUsers()})
+		_, err := s.Release(context.Background(), Actor{ID: "boss", Role: "admin"}, "t1")
+		wantErr(t, err, "ticket is not assigned")
+	})
+}
+
+func TestClaimAndReleaseNotify(t *testing.T) {
+	ctx := context.Background()
+	sent := &fakeNotifications{}
+	s := NewTicketService(TicketDeps{
+		Tickets: newFakeTickets(models.Ticket{ID: "t1", Status: "Open", CreatedBy: "u1"}),
+		Users:   newFakeUsers(models.User{ID: "a1", Name: "Ada", Role: "agent", Active: true}),
+		Watchers: &fakeWatchers{users: map[string][]models.User{"t1": {
+			{ID: "u1", Role: "end_user"},
+			{ID: "sup", Role: "supervisor"},
+		}}},
+		Notifier: newNotifier(sent),
+	})
+
+	if _, err := s.Claim(ctx, Actor{ID: "a1", Role: "agent"}, "t1"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if _, err := s.Release(ctx, Actor{ID: "sup2", Role: "admin"}, "t1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	want := map[string][]string{
+		"a1":  {"Unassigned; back in the queue"}, // the claimer is not told about their own claim
+		"u1":  {"Assigned to Ada", "Unassigned; back in the queue"},
+		"sup": {"Assigned to Ada", "Unassigned; back in the queue"},
+	}
+	for user, msgs := range want {
+		if got := sent.messages(user); !slices.Equal(got, msgs) {
+			t.Errorf("%s got %q, want %q", user, got, msgs)
+		}
+	}
+}
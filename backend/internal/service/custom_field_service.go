@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrCustomFieldNotFound = errors.New("custom field not found")
+
+var (
+	customFieldKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+	allowedCustomFieldTypes = map[string]struct{}{
+		models.FieldText:        {},
+		models.FieldNumber:      {},
+		models.FieldDate:        {},
+		models.FieldSelect:      {},
+		models.FieldMultiSelect: {},
+		models.FieldUser:        {},
+	}
+)
+
+// CustomFieldService manages admin-defined ticket fields. Values are
+// validated by TicketService on create/update.
+type CustomFieldService struct {
+	fields   repository.CustomFieldRepository
+	taxonomy *TaxonomyService
+}
+
+func NewCustomFieldService(fields repository.CustomFieldRepository, taxonomy *TaxonomyService) *CustomFieldService {
+	return &CustomFieldService{fields: fields, taxonomy: taxonomy}
+}
+
+func (s *CustomFieldService) List(ctx context.Context, activeOnly bool) ([]models.CustomField, error) {
+	return s.fields.List(ctx, activeOnly)
+}
+
+func (s *CustomFieldService) Create(ctx context.Context, f *models.CustomField) error {
+	f.ID = ""
+	f.Key = strings.TrimSpace(f.Key)
+	f.Type = strings.TrimSpace(f.Type)
+	if !customFieldKeyRe.MatchString(f.Key) {
+		return invalid("key must be 1-40 lowercase letters, digits or '_' starting with a letter")
+	}
+	if _, ok := allowedCustomFieldTypes[f.Type]; !ok {
+		return invalid("invalid type")
+	}
+	existing, err := s.fields.List(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other.Key, f.Key) {
+			return invalid("key already exists")
+		}
+	}
+	if err := s.validate(ctx, f); err != nil {
+		return err
+	}
+	return s.fields.Create(ctx, f)
+}
+
+// Update changes a field's label, options, scope, order and state. Key and
+// type are fixed once created since stored values depend on them.
+func (s *CustomFieldService) Update(ctx context.Context, f *models.CustomField) error {
+	existing, err := s.get(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+	if k := strings.TrimSpace(f.Key); k != "" && k != existing.Key {
+		return invalid("key cannot be changed")
+	}
+	if t := strings.TrimSpace(f.Type); t != "" && t != existing.Type {
+		return invalid("type cannot be changed")
+	}
+	f.Key, f.Type = existing.Key, existing.Type
+	if err := s.validate(ctx, f); err != nil {
+		return err
+	}
+	return s.fields.Update(ctx, f)
+}
+
+// Delete removes a field together with its values on all tickets.
+func (s *CustomFieldService) Delete(ctx context.Context, id string) error {
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	err := s.fields.Delete(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrCustomFieldNotFound
+	}
+	return err
+}
+
+func (s *CustomFieldService) validate(ctx context.Context, f *models.CustomField) error {
+	f.Label = strings.TrimSpace(f.Label)
+	if f.Label == "" {
+		return invalid("label is required")
+	}
+	if utf8.RuneCountInString(f.Label) > 80 {
+		return invalid("label is too long")
+	}
+
+	switch f.Type {
+	case models.FieldSelect, models.FieldMultiSelect:
+		f.Options = dedupeTrimmed(f.Options)
+		if len(f.Options) == 0 {
+			return invalid("options are required for select fields")
+		}
+	default:
+		f.Options = nil
+	}
+
+	f.Categories = dedupeTrimmed(f.Categories)
+	for _, c := range f.Categories {
+		if err := s.taxonomy.ValidateCategory(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CustomFieldService) get(ctx context.Context, id string) (*models.CustomField, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCustomFieldNotFound
+	}
+	f, err := s.fields.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, ErrCustomFieldNotFound
+	}
+	return f, nil
+}
+
+// dedupeTrimmed trims values and drops empty and repeated ones, keeping order.
+func dedupeTrimmed(in []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
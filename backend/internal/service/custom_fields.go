@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+)
+
+// maxCustomTextLen limits text custom field values (in characters).
+const maxCustomTextLen = 1000
+
+// applyCustomFields merges values into t.CustomFields: a nil value or an
+// empty text/list removes the key. Values are checked against the field
+// definitions and t's category. With checkRequired, required fields that
+// apply to t's category must be present afterwards.
+func (s *TicketService) applyCustomFields(ctx context.Context, t *models.Ticket, values map[string]any, checkRequired bool) error {
+	if s.fields == nil {
+		if len(values) > 0 {
+			return invalid("custom fields are not enabled")
+		}
+		return nil
+	}
+	defs, err := s.fields.List(ctx, false)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*models.CustomField, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	merged := make(map[string]any, len(t.CustomFields)+len(values))
+	for k, v := range t.CustomFields {
+		merged[k] = v
+	}
+	for key, raw := range values {
+		def := byKey[key]
+		if def == nil {
+			return invalid("unknown custom field: " + key)
+		}
+		if raw == nil {
+			delete(merged, key)
+			continue
+		}
+		if !def.Active {
+			return invalid("custom field " + key + " is inactive")
+		}
+		if !def.AppliesTo(t.Category) {
+			return invalid("custom field " + key + " does not apply to category " + t.Category)
+		}
+		v, err := s.customFieldValue(ctx, def, raw)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			delete(merged, key)
+		} else {
+			merged[key] = v
+		}
+	}
+
+	if checkRequired {
+		for _, def := range defs {
+			if def.Active && def.Required && def.AppliesTo(t.Category) {
+				if _, ok := merged[def.Key]; !ok {
+					return invalid(def.Label + " is required")
+				}
+			}
+		}
+	}
+	t.CustomFields = merged
+	return nil
+}
+
+// customFieldValue validates raw (as decoded from JSON) for def and returns
+// the value to store, or nil for "no value".
+func (s *TicketService) customFieldValue(ctx context.Context, def *models.CustomField, raw any) (any, error) {
+	bad := func(want string) error { return invalid(def.Key + " must be " + want) }
+
+	switch def.Type {
+	case models.FieldText:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, bad("a string")
+		}
+		if str = strings.TrimSpace(str); str == "" {
+			return nil, nil
+		}
+		if utf8.RuneCountInString(str) > maxCustomTextLen {
+			return nil, invalid(def.Key + " is too long")
+		}
+		return str, nil
+
+	case models.FieldNumber:
+		n, ok := raw.(float64)
+		if !ok {
+			return nil, bad("a number")
+		}
+		return n, nil
+
+	case models.FieldDate:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, bad("a date (YYYY-MM-DD)")
+		}
+		if str = strings.TrimSpace(str); str == "" {
+			return nil, nil
+		}
+		if _, err := time.Parse(time.DateOnly, str); err != nil {
+			return nil, bad("a date (YYYY-MM-DD)")
+		}
+		return str, nil
+
+	case models.FieldSelect:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, bad("one of its options")
+		}
+		if str = strings.TrimSpace(str); str == "" {
+			return nil, nil
+		}
+		if !slices.Contains(def.Options, str) {
+			return nil, bad("one of its options")
+		}
+		return str, nil
+
+	case models.FieldMultiSelect:
+		list, ok := raw.([]any)
+		if !ok {
+			return nil, bad("a list of its options")
+		}
+		out := []any{}
+		for _, item := range list {
+			str, ok := item.(string)
+			if !ok || !slices.Contains(def.Options, str) {
+				return nil, bad("a list of its options")
+			}
+			if !slices.Contains(out, any(str)) {
+				out = append(out, str)
+			}
+		}
+		if len(out) == 0 {
+			return nil, nil
+		}
+		return out, nil
+
+	case models.FieldUser:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, bad("a user id")
+		}
+		if str = strings.TrimSpace(str); str == "" {
+			return nil, nil
+		}
+		if _, err := uuid.Parse(str); err != nil {
+			return nil, bad("a user id")
+		}
+		u, err := s.users.GetByID(ctx, str)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil || !u.Active {
+			return nil, invalid(def.Key + ": user not found or inactive")
+		}
+		return str, nil
+	}
+	return nil, invalid("custom field " + def.Key + " has an unknown type")
+}
+
+// CustomFieldFilter turns "key → value" query parameters into the typed
+// containment filter for repository.TicketFilter.CustomFields.
+func (s *TicketService) CustomFieldFilter(ctx context.Context, raw map[string]string) (map[string]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if s.fields == nil {
+		return nil, invalid("custom fields are not enabled")
+	}
+	defs, err := s.fields.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]any, len(raw))
+	for key, v := range raw {
+		i := slices.IndexFunc(defs, func(f models.CustomField) bool { return f.Key == key })
+		if i < 0 {
+			return nil, invalid("unknown custom field: " + key)
+		}
+		switch defs[i].Type {
+		case models.FieldNumber:
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, invalid(key + " must be a number")
+			}
+			out[key] = n
+		case models.FieldMultiSelect:
+			out[key] = []any{v}
+		default:
+			out[key] = v
+		}
+	}
+	return out, nil
+}
+
+// diffCustomFields returns one change event per custom field whose value
+// differs between before and after.
+func diffCustomFields(before, after map[string]any, ticketID, actorID string) []models.TicketEvent {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var events []models.TicketEvent
+	for _, k := range keys {
+		old, cur := customFieldString(before[k]), customFieldString(after[k])
+		if old == cur {
+			continue
+		}
+		events = append(events, models.TicketEvent{
+			TicketID: ticketID,
+			ActorID:  actorID,
+			Type:     models.TicketEventChange,
+			Field:    "custom." + k,
+			OldValue: old,
+			NewValue: cur,
+		})
+	}
+	return events
+}
+
+// customFieldString renders a value for history: strings as-is, anything
+// else as JSON.
+func customFieldString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
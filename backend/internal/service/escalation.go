@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// EscalationAction is one thing the SLA worker may do to a ticket.
+type EscalationAction string
+
+const (
+	EscalateNote         EscalationAction = "note"          // add an internal note
+	EscalateBumpPriority EscalationAction = "bump_priority" // raise priority one step
+	EscalateReassign     EscalationAction = "reassign"      // hand over to a supervisor
+)
+
+// ParseEscalationActions parses a comma-separated action list, ignoring
+// unknown entries.
+func ParseEscalationActions(s string) []EscalationAction {
+	var out []EscalationAction
+	for _, a := range strings.Split(s, ",") {
+		switch act := EscalationAction(strings.TrimSpace(a)); act {
+		case EscalateNote, EscalateBumpPriority, EscalateReassign:
+			out = append(out, act)
+		}
+	}
+	return out
+}
+
+// Escalate applies SLA escalation actions to t and records them in the
+// ticket history as a system change. level is models.SLALevelAtRisk or
+// models.SLALevelBreached; a ticket is escalated at most once per level.
+func (s *TicketService) Escalate(ctx context.Context, t *models.Ticket, level int, actions []EscalationAction) error {
+	if t.SLA.EscalationLevel >= level {
+		return nil
+	}
+	before := *t
+
+	reason := "at_risk"
+	if level >= models.SLALevelBreached {
+		reason = "breached"
+	}
+
+	var taken []string
+	note := false
+	for _, a := range actions {
+		switch a {
+		case EscalateBumpPriority:
+			// one step up the priority order; the highest stays put
+			tax, err := s.taxonomy.Get(ctx)
+			if err != nil {
+				return err
+			}
+			if p := tax.NextPriority(t.Priority); p != "" {
+				t.Priority = p
+				taken = append(taken, string(a))
+			}
+		case EscalateReassign:
+			id, err := s.escalationAssignee(ctx)
+			if err != nil {
+				return err
+			}
+			if id != "" && id != t.Assignee {
+				t.Assignee = id
+				taken = append(taken, string(a))
+			}
+		case EscalateNote:
+			note = true
+			taken = append(taken, string(a))
+		}
+	}
+	t.SLA.EscalationLevel = level
+
+	events := diffTicket(&before, t, "")
+	events = append(events, models.TicketEvent{
+		TicketID: t.ID,
+		Type:     models.TicketEventSLAEscalation,
+		Field:    reason,
+		OldValue: fmt.Sprint(before.SLA.EscalationLevel),
+		NewValue: strings.Join(taken, ","),
+	})
+	if err := s.tickets.Update(ctx, t, events); err != nil {
+		return err
+	}
+	s.notifyChanges(ctx, "", &before, t)
+
+	if note {
+		text := "SLA escalation: ticket is at risk of missing its deadline."
+		if reason == "breached" {
+			text = "SLA escalation: ticket has breached its SLA."
+		}
+		if len(taken) > 1 {
+			text += " Actions: " + strings.Join(taken, ", ") + "."
+		}
+		if _, err := s.tickets.AddComment(ctx, t.ID, "", text, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escalationAssignee picks who breached tickets are handed to: the first
+// active supervisor, else the first active admin.
+func (s *TicketService) escalationAssignee(ctx context.Context) (string, error) {
+	id, err := s.users.FirstActiveIDByRole(ctx, "supervisor")
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, repository.ErrNoActiveUser) {
+		return "", err
+	}
+	id, err = s.users.FirstActiveAdminID(ctx)
+	if errors.Is(err, repository.ErrNoActiveAdmin) {
+		return "", nil
+	}
+	return id, err
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// The fakes embed their repository interface so that calling a method a
+// test did not expect panics instead of silently succeeding.
+
+// fakeUsers serves users from memory, keyed by id.
+type fakeUsers struct {
+	repository.UserRepository
+	byID map[string]*models.User
+}
+
+func newFakeUsers(users ...models.User) *fakeUsers {
+	f := &fakeUsers{byID: map[string]*models.User{}}
+	for i := range users {
+		f.byID[users[i].ID] = &users[i]
+	}
+	return f
+}
+
+func (f *fakeUsers) GetByID(_ context.Context, id string) (*models.User, error) {
+	return f.byID[id], nil
+}
+
+// FindByMention matches active users by email, or by name without spaces.
+func (f *fakeUsers) FindByMention(_ context.Context, handle string) ([]models.User, error) {
+	var out []models.User
+	for _, u := range f.byID {
+		key := strings.ReplaceAll(u.Name, " ", "")
+		if strings.Contains(handle, "@") {
+			key = u.Email
+		}
+		if u.Active && strings.EqualFold(key, handle) {
+			out = append(out, *u)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeUsers) FirstActiveAdminID(context.Context) (string, error) {
+	for _, u := range f.byID {
+		if u.Role == "admin" && u.Active {
+			return u.ID, nil
+		}
+	}
+	return "", repository.ErrNoActiveAdmin
+}
+
+// wantErr fails t unless err is nil (msg == "") or a ValidationError with msg.
+func wantErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	var ve *ValidationError
+	switch {
+	case msg == "" && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case msg != "" && (!errors.As(err, &ve) || ve.Msg != msg):
+		t.Fatalf("error = %v, want validation error %q", err, msg)
+	}
+}
+
+// fakeTickets keeps tickets in memory. Claim, release and updates check and
+// set under one lock, like the single statements of the Postgres repository.
+type fakeTickets struct {
+	repository.TicketRepository
+	mu   sync.Mutex
+	byID map[string]*models.Ticket
+}
+
+func newFakeTickets(tickets ...models.Ticket) *fakeTickets {
+	f := &fakeTickets{byID: map[string]*models.Ticket{}}
+	for i := range tickets {
+		t := tickets[i]
+		if t.Version == 0 {
+			t.Version = 1
+		}
+		f.byID[t.ID] = &t
+	}
+	return f
+}
+
+func (f *fakeTickets) Get(_ context.Context, id string) (*models.Ticket, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (f *fakeTickets) ClaimTicket(_ context.Context, ticketID, userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.byID[ticketID]
+	if t == nil || t.Assignee != "" {
+		return false, nil
+	}
+	t.Assignee = userID
+	t.Version++
+	return true, nil
+}
+
+func (f *fakeTickets) ReleaseTicket(_ context.Context, ticketID, assignee, _ string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.byID[ticketID]
+	if t == nil || t.Assignee == "" || (assignee != "" && t.Assignee != assignee) {
+		return false, nil
+	}
+	t.Assignee = ""
+	t.Version++
+	return true, nil
+}
+
+func (f *fakeTickets) Update(_ context.Context, t *models.Ticket, _ []models.TicketEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cur := f.byID[t.ID]
+	if cur == nil {
+		return repository.ErrNotFound
+	}
+	if cur.Version != t.Version {
+		return repository.ErrVersionConflict
+	}
+	t.Version++
+	cp := *t
+	f.byID[t.ID] = &cp
+	return nil
+}
+
+// UpdateBatch applies every update or, on a version conflict, none.
+func (f *fakeTickets) UpdateBatch(_ context.Context, updates []repository.TicketUpdate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range updates {
+		if cur := f.byID[u.Ticket.ID]; cur == nil || cur.Version != u.Ticket.Version {
+			return repository.ErrVersionConflict
+		}
+	}
+	for _, u := range updates {
+		u.Ticket.Version++
+		cp := *u.Ticket
+		f.byID[cp.ID] = &cp
+	}
+	return nil
+}
+
+func (f *fakeTickets) ResolveAlias(_ context.Context, alias string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, t := range f.byID {
+		if t.Alias != "" && strings.EqualFold(t.Alias, alias) {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// MergeTicket closes sourceID as merged into targetID unless either side
+// was merged already.
+func (f *fakeTickets) MergeTicket(_ context.Context, sourceID, targetID, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	source, target := f.byID[sourceID], f.byID[targetID]
+	if source == nil || target == nil || source.MergedInto != "" || target.MergedInto != "" {
+		return repository.ErrVersionConflict
+	}
+	source.MergedInto, source.Status = targetID, models.StatusClosed
+	source.Version++
+	target.Version++
+	return nil
+}
+
+func (f *fakeTickets) AddComment(_ context.Context, ticketID, authorID, text string, internal bool) (*models.Comment, error) {
+	return &models.Comment{ID: "c-" + ticketID, TicketID: ticketID, AuthorID: authorID, Text: text, Internal: internal, CreatedAt: time.Now()}, nil
+}
+
+func (f *fakeTickets) SetCommentMentions(context.Context, string, []models.Mention) error {
+	return nil
+}
+
+func (f *fakeTickets) MarkFirstResponse(context.Context, string, time.Time) error {
+	return nil
+}
+
+// fakeWatchers maps ticket ids to their watching users. Add and Remove
+// only track user ids.
+type fakeWatchers struct {
+	repository.WatcherRepository
+	users map[string][]models.User
+}
+
+func (f *fakeWatchers) Users(_ context.Context, ticketID string) ([]models.User, error) {
+	return f.users[ticketID], nil
+}
+
+func (f *fakeWatchers) List(_ context.Context, ticketID string) ([]models.Watcher, error) {
+	out := []models.Watcher{}
+	for _, u := range f.users[ticketID] {
+		out = append(out, models.Watcher{TicketID: ticketID, UserID: u.ID})
+	}
+	return out, nil
+}
+
+func (f *fakeWatchers) Add(_ context.Context, ticketID, userID, _, _ string) (bool, error) {
+	if f.watching(ticketID, userID) {
+		return false, nil
+	}
+	if f.users == nil {
+		f.users = map[string][]models.User{}
+	}
+	f.users[ticketID] = append(f.users[ticketID], models.User{ID: userID})
+	return true, nil
+}
+
+func (f *fakeWatchers) Remove(_ context.Context, ticketID, userID string) (bool, error) {
+	if !f.watching(ticketID, userID) {
+		return false, nil
+	}
+	f.users[ticketID] = slices.DeleteFunc(f.users[ticketID], func(u models.User) bool { return u.ID == userID })
+	return true, nil
+}
+
+func (f *fakeWatchers) watching(ticketID, userID string) bool {
+	return slices.ContainsFunc(f.users[ticketID], func(u models.User) bool { return u.ID == userID })
+}
+
+// fakeNotifications records delivered notifications per recipient.
+type fakeNotifications struct {
+	repository.NotificationRepository
+	mu   sync.Mutex
+	sent map[string][]models.Notification // user id -> notifications
+}
+
+func (f *fakeNotifications) Create(_ context.Context, n models.Notification, userIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sent == nil {
+		f.sent = map[string][]models.Notification{}
+	}
+	for _, id := range userIDs {
+		f.sent[id] = append(f.sent[id], n)
+	}
+	return nil
+}
+
+// messages returns the messages userID received, oldest first.
+func (f *fakeNotifications) messages(userID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for _, n := range f.sent[userID] {
+		out = append(out, n.Message)
+	}
+	return out
+}
+
+func newNotifier(repo *fakeNotifications) *NotificationService {
+	return NewNotificationService(repo, zerolog.Nop())
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrLinkNotFound = errors.New("link not found")
+
+// linkTypes maps the link types accepted from clients (relative to the ticket
+// in the URL) to the stored kind; swap means the URL ticket is the target.
+var linkTypes = map[string]struct {
+	kind string
+	swap bool
+}{
+	"parent_of":    {models.LinkParent, false},
+	"child_of":     {models.LinkParent, true},
+	"blocks":       {models.LinkBlocks, false},
+	"blocked_by":   {models.LinkBlocks, true},
+	"relates_to":   {models.LinkRelates, false},
+	"duplicate_of": {models.LinkDuplicate, false},
+}
+
+// AddLink links ticketID to otherID with typ (see linkTypes) and returns
+// ticketID's links. A ticket has at most one parent and the parent/child
+// hierarchy may not contain cycles.
+func (s *TicketService) AddLink(ctx context.Context, actor Actor, ticketID, otherID, typ string) ([]models.TicketLink, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	lt, ok := linkTypes[strings.ToLower(strings.TrimSpace(typ))]
+	if !ok {
+		return nil, invalid("invalid link type")
+	}
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	otherID, err = s.ResolveID(ctx, otherID)
+	if errors.Is(err, ErrTicketNotFound) {
+		return nil, invalid("linked ticket not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	other, err := s.tickets.Get(ctx, otherID)
+	if err != nil {
+		return nil, err
+	}
+	if other == nil {
+		return nil, invalid("linked ticket not found")
+	}
+	if other.ID == t.ID {
+		return nil, invalid("cannot link a ticket to itself")
+	}
+
+	source, target := t.ID, other.ID
+	if lt.swap {
+		source, target = target, source
+	}
+	if lt.kind == models.LinkRelates && source > target {
+		// symmetric: one canonical row per pair
+		source, target = target, source
+	}
+
+	exists, err := s.links.Exists(ctx, source, target, lt.kind)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, invalid("link already exists")
+	}
+	if lt.kind == models.LinkParent {
+		if has, err := s.links.HasParent(ctx, target); err != nil {
+			return nil, err
+		} else if has {
+			return nil, invalid("child ticket already has a parent")
+		}
+		if cyc, err := s.links.IsAncestor(ctx, target, source); err != nil {
+			return nil, err
+		} else if cyc {
+			return nil, invalid("link would create a parent/child cycle")
+		}
+	}
+
+	if _, err := s.links.Create(ctx, source, target, lt.kind, actor.ID); err != nil {
+		return nil, err
+	}
+	return s.links.List(ctx, t.ID)
+}
+
+// RemoveLink deletes a link of ticketID.
+func (s *TicketService) RemoveLink(ctx context.Context, actor Actor, ticketID, linkID string) error {
+	if !IsStaff(actor.Role) {
+		return ErrForbidden
+	}
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(linkID); err != nil {
+		return ErrLinkNotFound
+	}
+	err = s.links.Delete(ctx, t.ID, linkID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrLinkNotFound
+	}
+	return err
+}
+
+// VisibleLinks drops links to tickets the caller cannot see (end users only
+// see their own tickets).
+func VisibleLinks(actor Actor, links []models.TicketLink) []models.TicketLink {
+	if actor.Role != "end_user" {
+		return links
+	}
+	out := make([]models.TicketLink, 0, len(links))
+	for _, l := range links {
+		if l.OtherCreatedBy == actor.ID {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// checkOpenChildren refuses to resolve/close a parent that still has open
+// children unless the caller overrides it.
+func (s *TicketService) checkOpenChildren(ctx context.Context, t *models.Ticket, override bool) error {
+	if override || s.links == nil {
+		return nil
+	}
+	n, err := s.links.OpenChildren(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: ticket has %d open child ticket(s); set overrideOpenChildren to proceed", ErrInvalidTransition, n)
+	}
+	return nil
+}
+
+// ResolveID accepts a ticket UUID or alias (case-insensitive) and returns
+// the ticket's UUID, or ErrTicketNotFound.
+func (s *TicketService) ResolveID(ctx context.Context, idOrAlias string) (string, error) {
+	idOrAlias = strings.TrimSpace(idOrAlias)
+	if _, err := uuid.Parse(idOrAlias); err == nil {
+		return idOrAlias, nil
+	}
+	if idOrAlias == "" {
+		return "", ErrTicketNotFound
+	}
+	id, err := s.tickets.ResolveAlias(ctx, idOrAlias)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", ErrTicketNotFound
+	}
+	return id, nil
+}
+
+func (s *TicketService) getTicket(ctx context.Context, id string) (*models.Ticket, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	return t, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"gh-ts/internal/models"
+)
+
+// maxMentions caps how many distinct handles of one comment are resolved.
+const maxMentions = 20
+
+// mentionRe matches @email or @handle not preceded by a word character (so
+// plain email addresses in text are not mentions).
+var mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_.+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+|[\p{L}\p{N}_.-]+)`)
+
+// parseMentions returns the distinct handles mentioned in text, in order of
+// first appearance and without the '@'.
+func parseMentions(text string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range mentionRe.FindAllStringSubmatch(text, -1) {
+		h := strings.TrimRight(m[1], ".-")
+		if h == "" || seen[strings.ToLower(h)] {
+			continue
+		}
+		seen[strings.ToLower(h)] = true
+		out = append(out, h)
+		if len(out) == maxMentions {
+			break
+		}
+	}
+	return out
+}
+
+// resolveMentions maps handles to users. Handles that match no user or
+// several, or a user who could not read the comment (the ticket is not
+// theirs, or it is an internal note and they are not staff), are left as
+// plain text.
+func (s *TicketService) resolveMentions(ctx context.Context, t *models.Ticket, handles []string, internal bool) ([]models.Mention, error) {
+	out := []models.Mention{}
+	seen := map[string]bool{}
+	for _, h := range handles {
+		users, err := s.users.FindByMention(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if len(users) != 1 {
+			continue
+		}
+		u := users[0]
+		if seen[u.ID] || !CanViewTicket(Actor{ID: u.ID, Role: u.Role}, t) || (internal && !IsStaff(u.Role)) {
+			continue
+		}
+		seen[u.ID] = true
+		out = append(out, models.Mention{UserID: u.ID, Name: u.Name, Email: u.Email, Handle: h})
+	}
+	return out, nil
+}
+
+// syncMentions resolves the mentions in c, stores them and makes the
+// mentioned users watchers of t. It returns the users newly mentioned
+// compared with before.
+func (s *TicketService) syncMentions(ctx context.Context, actor Actor, t *models.Ticket, c *models.Comment, before []models.Mention) ([]models.Mention, error) {
+	mentions, err := s.resolveMentions(ctx, t, parseMentions(c.Text), c.Internal)
+	if err != nil {
+		return nil, err
+	}
+	if len(mentions) > 0 || len(before) > 0 {
+		if err := s.tickets.SetCommentMentions(ctx, c.ID, mentions); err != nil {
+			return nil, err
+		}
+	}
+	c.Mentions = mentions
+
+	var added []models.Mention
+	for _, m := range mentions {
+		if mentionedIn(before, m.UserID) {
+			continue
+		}
+		added = append(added, m)
+		if s.watchers != nil {
+			if _, err := s.watchers.Add(ctx, t.ID, m.UserID, models.WatchMention, actor.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return added, nil
+}
+
+func mentionedIn(mentions []models.Mention, userID string) bool {
+	for _, m := range mentions {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+func TestParseMentions(t *testing.T) {
+	many := make([]string, 0, maxMentions+5)
+	for i := range cap(many) {
+		many = append(many, fmt.Sprintf("@user%d", i))
+	}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"no mentions here", nil},
+		{"@alice please check", []string{"alice"}},
+		{"hi @alice, @Bob.", []string{"alice", "Bob"}},
+		{"@ALICE and @alice", []string{"ALICE"}},
+		{"@bob@example.com thanks", []string{"bob@example.com"}},
+		{"mail bob@example.com", nil},
+		{"a@b", nil},
+		{"@@x", nil},
+		{"(@jürgen)", []string{"jürgen"}},
+		{"cc @carol-", []string{"carol"}},
+		{"@a.b.c.", []string{"a.b.c"}},
+		{"@.", nil},
+		{"line one\n@dave", []string{"dave"}},
+		{strings.Join(many, " "), func() []string {
+			out := make([]string, maxMentions)
+			for i := range out {
+				out[i] = many[i][1:]
+			}
+			return out
+		}()},
+	}
+	for _, tt := range tests {
+		if got := parseMentions(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("parseMentions(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	s := NewTicketService(TicketDeps{Users: newFakeUsers(
+		models.User{ID: "a1", Name: "Ann Lee", Email: "ann@example.com", Role: "agent", Active: true},
+		models.User{ID: "a2", Name: "Sam Roe", Role: "agent", Active: true},
+		models.User{ID: "a3", Name: "Sam Roe", Role: "supervisor", Active: true},
+		models.User{ID: "a4", Name: "Old Timer", Role: "agent"},
+		models.User{ID: "u1", Name: "Rita Requester", Role: "end_user", Active: true},
+		models.User{ID: "u2", Name: "Otto Other", Role: "end_user", Active: true},
+	)})
+	ticket := &models.Ticket{ID: "t1", CreatedBy: "u1"}
+	handles := []string{"annlee", "ANN@example.com", "samroe", "oldtimer", "ritarequester", "ottoother", "nobody"}
+
+	tests := []struct {
+		name     string
+		internal bool
+		want     []string
+	}{
+		// Ann once (name and email), Sam is ambiguous, Old Timer inactive,
+		// Otto cannot see the ticket
+		{"public comment", false, []string{"a1", "u1"}},
+		{"internal note", true, []string{"a1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mentions, err := s.resolveMentions(context.Background(), ticket, handles, tt.internal)
+			if err != nil {
+				t.Fatalf("resolveMentions: %v", err)
+			}
+			var got []string
+			for _, m := range mentions {
+				got = append(got, m.UserID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("mentioned %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
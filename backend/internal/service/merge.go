@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+)
+
+// Merge folds the duplicate sourceID into targetID (UUID or alias): comments
+// and attachments move to the target and the source is closed with a pointer
+// to it. The source keeps its row and alias, so existing links still
+// resolve. Returns the updated target.
+func (s *TicketService) Merge(ctx context.Context, actor Actor, sourceID, targetID string) (*models.Ticket, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	if _, err := uuid.Parse(sourceID); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	targetID, err := s.ResolveID(ctx, targetID)
+	if errors.Is(err, ErrTicketNotFound) {
+		return nil, invalid("target ticket not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.tickets.Get(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, ErrTicketNotFound
+	}
+	target, err := s.tickets.Get(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, invalid("target ticket not found")
+	}
+
+	switch {
+	case source.ID == target.ID:
+		return nil, invalid("cannot merge a ticket into itself")
+	case source.MergedInto != "":
+		return nil, fmt.Errorf("%w: ticket was already merged into %s", ErrInvalidTransition, source.MergedInto)
+	case target.MergedInto != "":
+		return nil, invalid("target ticket was itself merged into " + target.MergedInto)
+	}
+
+	if err := s.tickets.MergeTicket(ctx, source.ID, target.ID, actor.ID); err != nil {
+		return nil, err
+	}
+	closed := *source
+	closed.Status = StatusClosed
+	s.notifyChanges(ctx, actor.ID, source, &closed)
+	return s.tickets.Get(ctx, target.ID)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+const (
+	sourceTicketID = "8c4b2a10-6e3d-4f5a-9b7c-2d1e0f9a8b01"
+	targetTicketID = "8c4b2a10-6e3d-4f5a-9b7c-2d1e0f9a8b02"
+	mergedTicketID = "8c4b2a10-6e3d-4f5a-9b7c-2d1e0f9a8b03"
+)
+
+func newMergeTickets() *fakeTickets {
+	return newFakeTickets(
+		models.Ticket{ID: sourceTicketID, Alias: "TKT-2026-00010", Status: "Open", CreatedBy: "u1"},
+		models.Ticket{ID: targetTicketID, Alias: "TKT-2026-00011", Status: "In Progress", CreatedBy: "u2"},
+		models.Ticket{ID: mergedTicketID, Alias: "TKT-2026-00012", Status: StatusClosed, MergedInto: targetTicketID},
+	)
+}
+
+func TestMerge(t *testing.T) {
+	agent := Actor{ID: "a1", Role: "agent"}
+	tests := []struct {
+		name           string
+		actor          Actor
+		source, target string
+		want           error
+		msg            string
+	}{
+		{"by id", agent, sourceTicketID, targetTicketID, nil, ""},
+		{"target by alias", agent, sourceTicketID, "tkt-2026-00011", nil, ""},
+		{"end user", Actor{ID: "u1", Role: "end_user"}, sourceTicketID, targetTicketID, ErrForbidden, ""},
+		{"malformed source", agent, "TKT-2026-00010", targetTicketID, ErrTicketNotFound, ""},
+		{"unknown source", agent, "8c4b2a10-6e3d-4f5a-9b7c-2d1e0f9a8bff", targetTicketID, ErrTicketNotFound, ""},
+		{"unknown target", agent, sourceTicketID, "TKT-2026-09999", nil, "target ticket not found"},
+		{"into itself", agent, sourceTicketID, "TKT-2026-00010", nil, "cannot merge a ticket into itself"},
+		{"source already merged", agent, mergedTicketID, targetTicketID, ErrInvalidTransition, ""},
+		{"target already merged", agent, sourceTicketID, mergedTicketID, nil, "target ticket was itself merged into " + targetTicketID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := newMergeTickets()
+			s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+			got, err := s.Merge(context.Background(), tt.actor, tt.source, tt.target)
+			if tt.msg != "" {
+				wantErr(t, err, tt.msg)
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Merge = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if got.ID != targetTicketID {
+				t.Fatalf("returned %s, want the target", got.ID)
+			}
+			source, _ := tickets.Get(context.Background(), sourceTicketID)
+			if source.MergedInto != targetTicketID || source.Status != StatusClosed {
+				t.Fatalf("source = %s merged into %q, want Closed into the target", source.Status, source.MergedInto)
+			}
+		})
+	}
+}
+
+// Concurrent merges of one duplicate: one wins, the rest see a conflict.
+func TestMergeRace(t *testing.T) {
+	tickets := newMergeTickets()
+	s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		wins int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(actor string) {
+			defer wg.Done()
+			_, err := s.Merge(context.Background(), Actor{ID: actor, Role: "agent"}, sourceTicketID, targetTicketID)
+			switch {
+			case err == nil:
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			case !errors.Is(err, ErrVersionConflict) && !errors.Is(err, ErrInvalidTransition):
+				t.Errorf("Merge: %v", err)
+			}
+		}(fmt.Sprintf("agent-%d", i))
+	}
+	wg.Wait()
+	if wins != 1 {
+		t.Fatalf("%d merges succeeded, want 1", wins)
+	}
+}
+
+func TestMergeNotifiesSourceWatchers(t *testing.T) {
+	notes := &fakeNotifications{}
+	s := NewTicketService(TicketDeps{
+		Tickets: newMergeTickets(),
+		Users:   newFakeUsers(),
+		Watchers: &fakeWatchers{users: map[string][]models.User{sourceTicketID: {
+			{ID: "a1", Role: "agent"},
+			{ID: "a2", Role: "agent"},
+			{ID: "u1", Role: "end_user"}, // the requester
+			{ID: "u3", Role: "end_user"},
+		}}},
+		Notifier: newNotifier(notes),
+	})
+	if _, err := s.Merge(context.Background(), Actor{ID: "a1", Role: "agent"}, sourceTicketID, targetTicketID); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	want := []string{"Status changed from Open to Closed"}
+	for _, id := range []string{"a2", "u1"} {
+		if got := notes.messages(id); !slices.Equal(got, want) {
+			t.Errorf("%s got %q, want %q", id, got, want)
+		}
+	}
+	for _, id := range []string{"a1", "u3"} { // the actor; a stranger to the ticket
+		if got := notes.messages(id); len(got) != 0 {
+			t.Errorf("%s got %q, want nothing", id, got)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationService serves a user's in-app notifications and delivers
+// new ones (see TicketService for when they are produced). Delivery is
+// best-effort: failures are logged, never returned.
+type NotificationService struct {
+	notifications repository.NotificationRepository
+	log           zerolog.Logger
+}
+
+func NewNotificationService(notifications repository.NotificationRepository, log zerolog.Logger) *NotificationService {
+	return &NotificationService{notifications: notifications, log: log}
+}
+
+func (s *NotificationService) List(ctx context.Context, actor Actor, unreadOnly bool, limit, offset int) ([]models.Notification, int, error) {
+	return s.notifications.List(ctx, actor.ID, unreadOnly, limit, offset)
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, actor Actor) (int, error) {
+	return s.notifications.UnreadCount(ctx, actor.ID)
+}
+
+// MarkRead marks one of actor's notifications read.
+func (s *NotificationService) MarkRead(ctx context.Context, actor Actor, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrNotificationNotFound
+	}
+	ok, err := s.notifications.MarkRead(ctx, actor.ID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks all of actor's notifications read and returns how many
+// were unread.
+func (s *NotificationService) MarkAllRead(ctx context.Context, actor Actor) (int, error) {
+	return s.notifications.MarkAllRead(ctx, actor.ID)
+}
+
+// Preferences returns whether each notification type is enabled for actor.
+func (s *NotificationService) Preferences(ctx context.Context, actor Actor) (map[string]bool, error) {
+	set, err := s.notifications.Preferences(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(models.NotificationTypes))
+	for _, typ := range models.NotificationTypes {
+		enabled, ok := set[typ]
+		out[typ] = enabled || !ok
+	}
+	return out, nil
+}
+
+// SetPreferences turns the given types on or off for actor; types not in
+// prefs keep their setting.
+func (s *NotificationService) SetPreferences(ctx context.Context, actor Actor, prefs map[string]bool) (map[string]bool, error) {
+	for typ := range prefs {
+		if !slices.Contains(models.NotificationTypes, typ) {
+			return nil, invalid("unknown notification type: " + typ)
+		}
+	}
+	if err := s.notifications.SetPreferences(ctx, actor.ID, prefs); err != nil {
+		return nil, err
+	}
+	return s.Preferences(ctx, actor)
+}
+
+// Notify delivers n to userIDs, except n's actor (no one is notified of
+// their own actions), blanks and repeats. Errors are logged.
+func (s *NotificationService) Notify(ctx context.Context, n models.Notification, userIDs []string) {
+	var to []string
+	for _, id := range userIDs {
+		if id == "" || id == n.ActorID || slices.Contains(to, id) {
+			continue
+		}
+		to = append(to, id)
+	}
+	if len(to) == 0 {
+		return
+	}
+	if err := s.notifications.Create(ctx, n, to); err != nil {
+		s.failed(err, n)
+	}
+}
+
+// failed logs an error that kept n from being delivered.
+func (s *NotificationService) failed(err error, n models.Notification) {
+	s.log.Error().Err(err).Str("type", n.Type).Str("ticket", n.TicketID).Msg("notification delivery failed")
+}
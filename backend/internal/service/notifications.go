@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gh-ts/internal/models"
+)
+
+// maxNotifyExcerpt limits how much of a comment a notification quotes (in
+// characters).
+const maxNotifyExcerpt = 140
+
+// The notify* helpers run after the change they report has been saved, so
+// they never fail the caller: lookup and delivery errors are logged by the
+// NotificationService.
+
+// notifyChanges tells the new assignee and the ticket's watchers about an
+// assignment, the former assignee and the watchers about an unassignment,
+// and the watchers about a status change, between before and after.
+// actorID is empty for system changes.
+func (s *TicketService) notifyChanges(ctx context.Context, actorID string, before, after *models.Ticket) {
+	if s.notifier == nil {
+		return
+	}
+	if after.Assignee != "" && after.Assignee != before.Assignee {
+		n := models.Notification{Type: models.NotifyAssigned, TicketID: after.ID, ActorID: actorID, Message: "Assigned to you"}
+		s.notifier.Notify(ctx, n, []string{after.Assignee})
+
+		name := after.Assignee
+		if u, err := s.users.GetByID(ctx, after.Assignee); err != nil {
+			s.notifier.failed(err, n)
+		} else if u != nil {
+			name = u.Name
+		}
+		n.Message = "Assigned to " + name
+		if watchers, ok := s.watcherIDs(ctx, after, n, false); ok {
+			watchers = slices.DeleteFunc(watchers, func(id string) bool { return id == after.Assignee })
+			s.notifier.Notify(ctx, n, watchers)
+		}
+	} else if after.Assignee == "" && before.Assignee != "" {
+		n := models.Notification{Type: models.NotifyAssigned, TicketID: after.ID, ActorID: actorID, Message: "Unassigned; back in the queue"}
+		if watchers, ok := s.watcherIDs(ctx, after, n, false); ok {
+			s.notifier.Notify(ctx, n, append(watchers, before.Assignee))
+		}
+	}
+	if after.Status != before.Status {
+		n := models.Notification{
+			Type:     models.NotifyStatusChanged,
+			TicketID: after.ID,
+			ActorID:  actorID,
+			Message:  "Status changed from " + before.Status + " to " + after.Status,
+		}
+		if watchers, ok := s.watcherIDs(ctx, after, n, false); ok {
+			s.notifier.Notify(ctx, n, watchers)
+		}
+	}
+}
+
+// notifyComment tells the watchers of t about comment c, except the users
+// in skip (who get a mention instead). Only staff hear of internal notes.
+func (s *TicketService) notifyComment(ctx context.Context, actorID string, t *models.Ticket, c *models.Comment, skip []models.Mention) {
+	if s.notifier == nil {
+		return
+	}
+	n := models.Notification{
+		Type:     models.NotifyCommented,
+		TicketID: t.ID,
+		ActorID:  actorID,
+		Message:  excerpt(c.Text),
+	}
+	watchers, ok := s.watcherIDs(ctx, t, n, c.Internal)
+	if !ok {
+		return
+	}
+	watchers = slices.DeleteFunc(watchers, func(id string) bool { return mentionedIn(skip, id) })
+	s.notifier.Notify(ctx, n, watchers)
+}
+
+// notifyMentions tells mentioned users that c mentions them.
+func (s *TicketService) notifyMentions(ctx context.Context, actorID string, t *models.Ticket, c *models.Comment, mentions []models.Mention) {
+	if s.notifier == nil || len(mentions) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(mentions))
+	for _, m := range mentions {
+		ids = append(ids, m.UserID)
+	}
+	s.notifier.Notify(ctx, models.Notification{
+		Type:     models.NotifyMentioned,
+		TicketID: t.ID,
+		ActorID:  actorID,
+		Message:  excerpt(c.Text),
+	}, ids)
+}
+
+// watcherIDs returns the watchers of t who may read it (see CanViewTicket),
+// only staff with staffOnly. Without a watcher repository nobody watches.
+// ok is false if the lookup for n failed (and was logged).
+func (s *TicketService) watcherIDs(ctx context.Context, t *models.Ticket, n models.Notification, staffOnly bool) (ids []string, ok bool) {
+	if s.watchers == nil {
+		return nil, true
+	}
+	users, err := s.watchers.Users(ctx, t.ID)
+	if err != nil {
+		s.notifier.failed(err, n)
+		return nil, false
+	}
+	for _, u := range users {
+		if (staffOnly && !IsStaff(u.Role)) || !CanViewTicket(Actor{ID: u.ID, Role: u.Role}, t) {
+			continue
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, true
+}
+
+// excerpt shortens text to maxNotifyExcerpt characters on one line.
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxNotifyExcerpt {
+		return text
+	}
+	return string([]rune(text)[:maxNotifyExcerpt-1]) + "…"
+}
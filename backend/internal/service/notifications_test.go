@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("x", maxNotifyExcerpt+10)
+	umlauts := strings.Repeat("ü", maxNotifyExcerpt)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", ""},
+		{"short note", "short note"},
+		{"  spread \n over\t\tlines  ", "spread over lines"},
+		{umlauts, umlauts},
+		{umlauts + "ü", strings.Repeat("ü", maxNotifyExcerpt-1) + "…"},
+		{long, long[:maxNotifyExcerpt-1] + "…"},
+	}
+	for _, tt := range tests {
+		if got := excerpt(tt.text); got != tt.want {
+			t.Errorf("excerpt(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// commentWatchers watch watchedTicketID, which requesterID opened.
+var commentWatchers = []models.User{
+	{ID: agentID, Role: "agent"},
+	{ID: "s1", Role: "supervisor"},
+	{ID: requesterID, Role: "end_user"},
+	{ID: strangerID, Role: "end_user"}, // no longer allowed to see the ticket
+}
+
+func TestCommentFanOut(t *testing.T) {
+	tests := []struct {
+		name     string
+		actor    Actor
+		text     string
+		internal bool
+		want     map[string][]string // recipient -> messages
+		mention  string              // recipient notified of a mention, not the comment
+	}{
+		{
+			name:  "public reply",
+			actor: Actor{ID: agentID, Role: "agent"},
+			text:  "Rebooted the   printer.",
+			want: map[string][]string{
+				"s1":        {"Rebooted the printer."},
+				requesterID: {"Rebooted the printer."},
+			},
+		},
+		{
+			name:     "internal note",
+			actor:    Actor{ID: agentID, Role: "agent"},
+			text:     "Probably the fuser again",
+			internal: true,
+			want:     map[string][]string{"s1": {"Probably the fuser again"}},
+		},
+		{
+			name:  "requester reply",
+			actor: Actor{ID: requesterID, Role: "end_user"},
+			text:  "Still broken",
+			want: map[string][]string{
+				agentID: {"Still broken"},
+				"s1":    {"Still broken"},
+			},
+		},
+		{
+			name:  "mentioned watcher gets only the mention",
+			actor: Actor{ID: agentID, Role: "agent"},
+			text:  "@RitaRequester can you confirm?",
+			want: map[string][]string{
+				"s1":        {"@RitaRequester can you confirm?"},
+				requesterID: {"@RitaRequester can you confirm?"},
+			},
+			mention: requesterID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes := &fakeNotifications{}
+			s := NewTicketService(TicketDeps{
+				Tickets: newFakeTickets(models.Ticket{ID: watchedTicketID, Status: "Open", CreatedBy: requesterID}),
+				Users: newFakeUsers(
+					models.User{ID: requesterID, Name: "Rita Requester", Role: "end_user", Active: true},
+				),
+				Watchers: &fakeWatchers{users: map[string][]models.User{watchedTicketID: slices.Clone(commentWatchers)}},
+				Notifier: newNotifier(notes),
+			})
+			if _, err := s.AddComment(context.Background(), tt.actor, watchedTicketID, tt.text, tt.internal); err != nil {
+				t.Fatalf("AddComment: %v", err)
+			}
+			for _, u := range commentWatchers {
+				if got := notes.messages(u.ID); !slices.Equal(got, tt.want[u.ID]) {
+					t.Errorf("%s got %q, want %q", u.ID, got, tt.want[u.ID])
+				}
+				want := models.NotifyCommented
+				if u.ID == tt.mention {
+					want = models.NotifyMentioned
+				}
+				for _, n := range notes.sent[u.ID] {
+					if n.Type != want {
+						t.Errorf("%s got a %s notification, want %s", u.ID, n.Type, want)
+					}
+				}
+			}
+		})
+	}
+}
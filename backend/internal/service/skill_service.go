@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrSkillNotFound = errors.New("skill not found")
+)
+
+// SkillService manages agent skill profiles used by skill-based routing.
+type SkillService struct {
+	skills repository.SkillRepository
+	users  repository.UserRepository
+}
+
+func NewSkillService(skills repository.SkillRepository, users repository.UserRepository) *SkillService {
+	return &SkillService{skills: skills, users: users}
+}
+
+func (s *SkillService) List(ctx context.Context, userID string) ([]models.UserSkill, error) {
+	if _, err := s.requireUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.skills.List(ctx, userID)
+}
+
+// Set adds or updates a skill on a staff user.
+func (s *SkillService) Set(ctx context.Context, sk *models.UserSkill) error {
+	sk.Skill = strings.TrimSpace(sk.Skill)
+	if sk.Skill == "" {
+		return invalid("skill is required")
+	}
+	if len(sk.Skill) > 64 {
+		return invalid("skill is too long")
+	}
+	if sk.Proficiency == 0 {
+		sk.Proficiency = 3
+	}
+	if sk.Proficiency < 1 || sk.Proficiency > 5 {
+		return invalid("proficiency must be between 1 and 5")
+	}
+	u, err := s.requireUser(ctx, sk.UserID)
+	if err != nil {
+		return err
+	}
+	if _, ok := allowedAssigneeRoles[strings.ToLower(u.Role)]; !ok {
+		return invalid("skills can only be set on staff users")
+	}
+	return s.skills.Upsert(ctx, sk)
+}
+
+func (s *SkillService) Delete(ctx context.Context, userID, skill string) error {
+	if _, err := s.requireUser(ctx, userID); err != nil {
+		return err
+	}
+	err := s.skills.Delete(ctx, userID, strings.TrimSpace(skill))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrSkillNotFound
+	}
+	return err
+}
+
+func (s *SkillService) requireUser(ctx context.Context, userID string) (*models.User, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrSLAPolicyNotFound = errors.New("sla policy not found")
+
+// SLAService assigns SLA policies to tickets and maintains their deadlines.
+// Targets count working time only, per the ticket department's calendar.
+type SLAService struct {
+	policies  repository.SLAPolicyRepository
+	calendars *CalendarService
+	taxonomy  *TaxonomyService
+}
+
+// NewSLAService builds the service. With a nil CalendarService targets are
+// measured on the wall clock (24x7).
+func NewSLAService(policies repository.SLAPolicyRepository, calendars *CalendarService, taxonomy *TaxonomyService) *SLAService {
+	return &SLAService{policies: policies, calendars: calendars, taxonomy: taxonomy}
+}
+
+// Apply picks the matching policy for t and (re)computes its deadlines from
+// t.CreatedAt in business time, pushed back by business time already spent
+// paused. A first-response deadline that has already been met is left alone.
+// Tickets without a matching policy get no deadlines.
+func (s *SLAService) Apply(ctx context.Context, t *models.Ticket) error {
+	p, err := s.policies.Match(ctx, t.Priority, t.Category, t.Department)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		t.SLA.PolicyID = ""
+		t.SLA.FirstResponseDue = nil
+		t.SLA.ResolutionDue = nil
+		return nil
+	}
+
+	cal, err := s.calendars.For(ctx, t.Department)
+	if err != nil {
+		return err
+	}
+
+	paused := time.Duration(t.SLA.PausedSeconds) * time.Second
+	t.SLA.PolicyID = p.ID
+	t.SLA.EscalationLevel = models.SLALevelNone // new deadlines, fresh escalation
+	if t.SLA.FirstRespondedAt == nil {
+		due := cal.Add(t.CreatedAt, time.Duration(p.FirstResponseMinutes)*time.Minute+paused)
+		t.SLA.FirstResponseDue = &due
+	}
+	due := cal.Add(t.CreatedAt, time.Duration(p.ResolutionMinutes)*time.Minute+paused)
+	t.SLA.ResolutionDue = &due
+	return nil
+}
+
+// Pause stops the SLA clock (ticket entered Pending).
+func (s *SLAService) Pause(t *models.Ticket, at time.Time) {
+	if t.SLA.PausedAt == nil {
+		t.SLA.PausedAt = &at
+	}
+}
+
+// Resume restarts the SLA clock and pushes open deadlines back by the
+// business time spent paused.
+func (s *SLAService) Resume(ctx context.Context, t *models.Ticket, at time.Time) error {
+	if t.SLA.PausedAt == nil {
+		return nil
+	}
+	cal, err := s.calendars.For(ctx, t.Department)
+	if err != nil {
+		return err
+	}
+	d := cal.Between(*t.SLA.PausedAt, at)
+	t.SLA.PausedAt = nil
+	if d <= 0 {
+		return nil
+	}
+	t.SLA.PausedSeconds += int64(d / time.Second)
+	if t.SLA.FirstResponseDue != nil && t.SLA.FirstRespondedAt == nil {
+		due := cal.Add(*t.SLA.FirstResponseDue, d)
+		t.SLA.FirstResponseDue = &due
+	}
+	if t.SLA.ResolutionDue != nil {
+		due := cal.Add(*t.SLA.ResolutionDue, d)
+		t.SLA.ResolutionDue = &due
+	}
+	return nil
+}
+
+// BusinessDuration returns the working time between a and b for tickets of
+// the given department.
+func (s *SLAService) BusinessDuration(ctx context.Context, department string, a, b time.Time) (time.Duration, error) {
+	cal, err := s.calendars.For(ctx, department)
+	if err != nil {
+		return 0, err
+	}
+	return cal.Between(a, b), nil
+}
+
+// -----------------------------------------------------------------------------
+// Policy administration
+// -----------------------------------------------------------------------------
+
+func (s *SLAService) ListPolicies(ctx context.Context) ([]models.SLAPolicy, error) {
+	return s.policies.List(ctx)
+}
+
+func (s *SLAService) CreatePolicy(ctx context.Context, p *models.SLAPolicy) error {
+	if err := s.validatePolicy(ctx, p); err != nil {
+		return err
+	}
+	return s.policies.Create(ctx, p)
+}
+
+func (s *SLAService) UpdatePolicy(ctx context.Context, p *models.SLAPolicy) error {
+	if _, err := uuid.Parse(p.ID); err != nil {
+		return ErrSLAPolicyNotFound
+	}
+	existing, err := s.policies.Get(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrSLAPolicyNotFound
+	}
+	if err := s.validatePolicy(ctx, p); err != nil {
+		return err
+	}
+	p.CreatedAt = existing.CreatedAt
+	if err := s.policies.Update(ctx, p); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSLAPolicyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SLAService) DeletePolicy(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrSLAPolicyNotFound
+	}
+	existing, err := s.policies.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrSLAPolicyNotFound
+	}
+	if err := s.policies.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSLAPolicyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SLAService) validatePolicy(ctx context.Context, p *models.SLAPolicy) error {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Priority = strings.TrimSpace(p.Priority)
+	p.Category = strings.TrimSpace(p.Category)
+	p.Department = strings.TrimSpace(p.Department)
+	if p.Name == "" {
+		return invalid("name is required")
+	}
+	if err := s.taxonomy.ValidatePriority(ctx, p.Priority); err != nil {
+		return err
+	}
+	if err := s.taxonomy.ValidateCategory(ctx, p.Category); err != nil {
+		return err
+	}
+	if p.FirstResponseMinutes <= 0 || p.ResolutionMinutes <= 0 {
+		return invalid("targets must be positive minutes")
+	}
+	if p.FirstResponseMinutes > p.ResolutionMinutes {
+		return invalid("first response target exceeds resolution target")
+	}
+	existing, err := s.policies.GetByScope(ctx, p.Priority, p.Category, p.Department)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != p.ID {
+		return invalid("a policy for this scope already exists")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+// fakeSLAPolicies keeps policies in memory, keyed by id.
+type fakeSLAPolicies struct {
+	repository.SLAPolicyRepository
+	byID map[string]*models.SLAPolicy
+}
+
+func (f *fakeSLAPolicies) Get(_ context.Context, id string) (*models.SLAPolicy, error) {
+	return f.byID[id], nil
+}
+
+func (f *fakeSLAPolicies) GetByScope(_ context.Context, priority, category, department string) (*models.SLAPolicy, error) {
+	for _, p := range f.byID {
+		if p.Priority == priority && p.Category == category && p.Department == department {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSLAPolicies) Delete(_ context.Context, id string) error {
+	if f.byID[id] == nil {
+		return repository.ErrNotFound
+	}
+	delete(f.byID, id)
+	return nil
+}
+
+const (
+	highPolicyID    = "6f1c1d4e-2d7b-4c8e-9a53-0d5a3b1e7c01"
+	networkPolicyID = "6f1c1d4e-2d7b-4c8e-9a53-0d5a3b1e7c02"
+)
+
+func newTestSLAService() *SLAService {
+	return NewSLAService(&fakeSLAPolicies{byID: map[string]*models.SLAPolicy{
+		highPolicyID:    {ID: highPolicyID, Name: "High", Priority: "High", FirstResponseMinutes: 60, ResolutionMinutes: 240},
+		networkPolicyID: {ID: networkPolicyID, Name: "High network", Priority: "High", Category: "Network", FirstResponseMinutes: 30, ResolutionMinutes: 120},
+	}}, nil, nil)
+}
+
+func TestValidatePolicy(t *testing.T) {
+	valid := func(mod func(p *models.SLAPolicy)) *models.SLAPolicy {
+		p := &models.SLAPolicy{Name: "Low", Priority: "Low", FirstResponseMinutes: 60, ResolutionMinutes: 480}
+		if mod != nil {
+			mod(p)
+		}
+		return p
+	}
+	tests := []struct {
+		name string
+		p    *models.SLAPolicy
+		want string
+	}{
+		{"valid", valid(nil), ""},
+		{"blank name", valid(func(p *models.SLAPolicy) { p.Name = "  " }), "name is required"},
+		{"unknown priority", valid(func(p *models.SLAPolicy) { p.Priority = "Urgent" }), "invalid priority"},
+		{"unknown category", valid(func(p *models.SLAPolicy) { p.Category = "Plumbing" }), "invalid category"},
+		{"zero first response", valid(func(p *models.SLAPolicy) { p.FirstResponseMinutes = 0 }), "targets must be positive minutes"},
+		{"negative resolution", valid(func(p *models.SLAPolicy) { p.ResolutionMinutes = -5 }), "targets must be positive minutes"},
+		{"first response after resolution", valid(func(p *models.SLAPolicy) { p.FirstResponseMinutes = 600 }), "first response target exceeds resolution target"},
+		{"duplicate scope", valid(func(p *models.SLAPolicy) { p.Priority = "High" }), "a policy for this scope already exists"},
+		{"duplicate scope after trimming", valid(func(p *models.SLAPolicy) { p.Priority, p.Category = " High", "Network " }), "a policy for this scope already exists"},
+		{"same priority, other category", valid(func(p *models.SLAPolicy) { p.Priority, p.Category = "High", "Hardware" }), ""},
+		{"same priority, other department", valid(func(p *models.SLAPolicy) { p.Priority, p.Department = "High", "IT" }), ""},
+		{"updating the scope's own policy", valid(func(p *models.SLAPolicy) { p.ID, p.Priority = highPolicyID, "High" }), ""},
+		{"moving onto another policy's scope", valid(func(p *models.SLAPolicy) {
+			p.ID, p.Priority, p.Category = highPolicyID, "High", "Network"
+		}), "a policy for this scope already exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantErr(t, newTestSLAService().validatePolicy(context.Background(), tt.p), tt.want)
+		})
+	}
+}
+
+func TestSLAPolicyNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSLAService()
+	for _, id := range []string{"", "not-a-uuid", "6f1c1d4e-2d7b-4c8e-9a53-0d5a3b1e7cff"} {
+		if err := s.UpdatePolicy(ctx, &models.SLAPolicy{ID: id}); !errors.Is(err, ErrSLAPolicyNotFound) {
+			t.Errorf("UpdatePolicy(%q) = %v, want ErrSLAPolicyNotFound", id, err)
+		}
+		if err := s.DeletePolicy(ctx, id); !errors.Is(err, ErrSLAPolicyNotFound) {
+			t.Errorf("DeletePolicy(%q) = %v, want ErrSLAPolicyNotFound", id, err)
+		}
+	}
+	if err := s.DeletePolicy(ctx, highPolicyID); err != nil {
+		t.Fatalf("DeletePolicy: %v", err)
+	}
+}
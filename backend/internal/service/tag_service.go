@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrTagNotFound = errors.New("tag not found")
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// maxTagLen limits tag names (in characters).
+const maxTagLen = 40
+
+// TagService manages tags and tagging of tickets. With adHoc set, staff
+// create unknown tags while tagging; otherwise only admins create tags.
+type TagService struct {
+	tags    repository.TagRepository
+	tickets repository.TicketRepository
+	adHoc   bool
+}
+
+func NewTagService(tags repository.TagRepository, tickets repository.TicketRepository, adHoc bool) *TagService {
+	return &TagService{tags: tags, tickets: tickets, adHoc: adHoc}
+}
+
+func (s *TagService) List(ctx context.Context) ([]models.Tag, error) {
+	return s.tags.List(ctx)
+}
+
+func (s *TagService) Create(ctx context.Context, actor Actor, t *models.Tag) error {
+	t.ID = ""
+	t.CreatedBy = actor.ID
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.tags.Create(ctx, t)
+}
+
+func (s *TagService) Update(ctx context.Context, t *models.Tag) error {
+	if _, err := s.get(ctx, t.ID); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.tags.Update(ctx, t)
+}
+
+// Delete removes a tag and untags all tickets carrying it.
+func (s *TagService) Delete(ctx context.Context, id string) error {
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	err := s.tags.Delete(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTagNotFound
+	}
+	return err
+}
+
+// AddToTicket tags a ticket with names and returns the ticket's tags.
+// Unknown names are created if ad hoc tags are enabled, else rejected.
+func (s *TagService) AddToTicket(ctx context.Context, actor Actor, ticketID string, names []string) ([]string, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	t, err := s.ticket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, invalid("tags are required")
+	}
+
+	tags := make([]*models.Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if err := validateTagName(name); err != nil {
+			return nil, err
+		}
+		tag, err := s.tags.GetByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if tag == nil {
+			if !s.adHoc {
+				return nil, invalid("unknown tag: " + name)
+			}
+			tag = &models.Tag{Name: name, CreatedBy: actor.ID}
+			if err := s.tags.Create(ctx, tag); err != nil {
+				return nil, err
+			}
+		}
+		tags = append(tags, tag)
+	}
+	for _, tag := range tags {
+		if _, err := s.tags.Attach(ctx, t.ID, tag, actor.ID); err != nil {
+			return nil, err
+		}
+	}
+	return s.ticketTags(ctx, t.ID)
+}
+
+// RemoveFromTicket untags a ticket.
+func (s *TagService) RemoveFromTicket(ctx context.Context, actor Actor, ticketID, name string) error {
+	if !IsStaff(actor.Role) {
+		return ErrForbidden
+	}
+	t, err := s.ticket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	tag, err := s.tags.GetByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		return ErrTagNotFound
+	}
+	ok, err := s.tags.Detach(ctx, t.ID, tag, actor.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// Counts returns open/total ticket counts per tag for reports.
+func (s *TagService) Counts(ctx context.Context) ([]models.TagCount, error) {
+	return s.tags.Counts(ctx)
+}
+
+func (s *TagService) validate(ctx context.Context, t *models.Tag) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Color = strings.TrimSpace(t.Color)
+	if err := validateTagName(t.Name); err != nil {
+		return err
+	}
+	if t.Color != "" && !hexColorRe.MatchString(t.Color) {
+		return invalid("color must look like #rrggbb")
+	}
+	other, err := s.tags.GetByName(ctx, t.Name)
+	if err != nil {
+		return err
+	}
+	if other != nil && other.ID != t.ID {
+		return invalid("tag already exists")
+	}
+	return nil
+}
+
+func validateTagName(name string) error {
+	if name == "" {
+		return invalid("tag name is required")
+	}
+	if utf8.RuneCountInString(name) > maxTagLen {
+		return invalid("tag name is too long")
+	}
+	if strings.ContainsAny(name, ",\n\r\t") {
+		return invalid("tag name may not contain commas or control characters")
+	}
+	return nil
+}
+
+func (s *TagService) get(ctx context.Context, id string) (*models.Tag, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTagNotFound
+	}
+	t, err := s.tags.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTagNotFound
+	}
+	return t, nil
+}
+
+func (s *TagService) ticket(ctx context.Context, id string) (*models.Ticket, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	return t, nil
+}
+
+func (s *TagService) ticketTags(ctx context.Context, id string) ([]string, error) {
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	return t.Tags, nil
+}
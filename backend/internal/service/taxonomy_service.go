@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrTaxonomyNotFound = errors.New("taxonomy entry not found")
+
+// taxonomyTTL bounds how stale another instance's cached taxonomy can be;
+// writes through this service refresh it immediately.
+const taxonomyTTL = 30 * time.Second
+
+// Lifecycle statuses the service relies on by name (system statuses).
+const (
+	StatusNew      = models.StatusNew
+	StatusPending  = models.StatusPending
+	StatusResolved = models.StatusResolved
+	StatusClosed   = models.StatusClosed
+)
+
+// defaultTaxonomy is used without a repository; it matches the seed data.
+var defaultTaxonomy = func() *models.Taxonomy {
+	t := &models.Taxonomy{}
+	for i, name := range []string{StatusNew, "Open", "In Progress", StatusPending, StatusResolved, StatusClosed} {
+		t.Statuses = append(t.Statuses, models.TicketStatus{
+			Name:     name,
+			Position: (i + 1) * 10,
+			Terminal: name == StatusResolved || name == StatusClosed,
+			System:   name == StatusNew || name == StatusPending || name == StatusResolved || name == StatusClosed,
+			Next:     DefaultStatusTransitions[name],
+			Active:   true,
+		})
+	}
+	for i, name := range []string{"Low", "Medium", "High", "Critical"} {
+		t.Priorities = append(t.Priorities, models.TicketPriority{
+			Name: name, Position: (i + 1) * 10, Default: i == 0, High: i >= 2, Active: true,
+		})
+	}
+	for i, name := range []string{"Software", "Hardware", "Network", "Access", "General"} {
+		t.Categories = append(t.Categories, models.TicketCategory{Name: name, Position: (i + 1) * 10, Active: true})
+	}
+	return t
+}()
+
+// TaxonomyService manages the admin-defined ticket statuses, priorities and
+// categories and answers validation questions from a short-lived cache.
+// A nil *TaxonomyService (or one without a repository) serves the defaults.
+type TaxonomyService struct {
+	repo repository.TaxonomyRepository
+	now  func() time.Time
+
+	mu       sync.Mutex
+	cached   *models.Taxonomy
+	loadedAt time.Time
+}
+
+func NewTaxonomyService(repo repository.TaxonomyRepository) *TaxonomyService {
+	return &TaxonomyService{repo: repo, now: time.Now}
+}
+
+// Get returns the current taxonomy. Callers must not modify it.
+func (s *TaxonomyService) Get(ctx context.Context) (*models.Taxonomy, error) {
+	if s == nil || s.repo == nil {
+		return defaultTaxonomy, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && s.now().Sub(s.loadedAt) < taxonomyTTL {
+		return s.cached, nil
+	}
+	t, err := s.repo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cached, s.loadedAt = t, s.now()
+	return t, nil
+}
+
+func (s *TaxonomyService) invalidate() {
+	s.mu.Lock()
+	s.cached = nil
+	s.mu.Unlock()
+}
+
+// ValidateStatus checks that status exists and is active.
+func (s *TaxonomyService) ValidateStatus(ctx context.Context, status string) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if st := t.Status(status); st == nil || !st.Active {
+		return invalid("invalid status")
+	}
+	return nil
+}
+
+// ValidatePriority checks that priority exists and is active.
+func (s *TaxonomyService) ValidatePriority(ctx context.Context, priority string) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if p := t.Priority(priority); p == nil || !p.Active {
+		return invalid("invalid priority")
+	}
+	return nil
+}
+
+// ValidateCategory checks that category is empty or exists and is active.
+func (s *TaxonomyService) ValidateCategory(ctx context.Context, category string) error {
+	if category == "" {
+		return nil
+	}
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if c := t.Category(category); c == nil || !c.Active {
+		return invalid("invalid category")
+	}
+	return nil
+}
+
+// IsTerminal reports whether status ends the ticket lifecycle.
+func (s *TaxonomyService) IsTerminal(ctx context.Context, status string) (bool, error) {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	return t.IsTerminal(status), nil
+}
+
+// Transitions returns the status transition table from the status list.
+func (s *TaxonomyService) Transitions(ctx context.Context) (StatusTransitions, error) {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st := StatusTransitions{}
+	for _, status := range t.Statuses {
+		st[status.Name] = status.Next
+	}
+	return st, nil
+}
+
+// -----------------------------------------------------------------------------
+// Admin CRUD
+// -----------------------------------------------------------------------------
+
+func (s *TaxonomyService) CreateStatus(ctx context.Context, st *models.TicketStatus) error {
+	st.ID, st.System = "", false
+	if err := s.validateStatus(ctx, st, nil); err != nil {
+		return err
+	}
+	return s.write(s.repo.CreateStatus(ctx, st))
+}
+
+// UpdateStatus changes a status. System statuses keep their name and
+// terminal flag since the lifecycle depends on them.
+func (s *TaxonomyService) UpdateStatus(ctx context.Context, st *models.TicketStatus) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	existing := findByID(t.Statuses, st.ID, func(x models.TicketStatus) string { return x.ID })
+	if existing == nil {
+		return ErrTaxonomyNotFound
+	}
+	if err := s.validateStatus(ctx, st, existing); err != nil {
+		return err
+	}
+	return s.write(s.repo.UpdateStatus(ctx, st))
+}
+
+func (s *TaxonomyService) DeleteStatus(ctx context.Context, id string) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	existing := findByID(t.Statuses, id, func(x models.TicketStatus) string { return x.ID })
+	if existing == nil {
+		return ErrTaxonomyNotFound
+	}
+	if existing.System {
+		return invalid("system statuses cannot be deleted")
+	}
+	return s.write(s.repo.DeleteStatus(ctx, id))
+}
+
+func (s *TaxonomyService) CreatePriority(ctx context.Context, p *models.TicketPriority) error {
+	p.ID = ""
+	if err := s.validatePriority(ctx, p); err != nil {
+		return err
+	}
+	return s.write(s.repo.CreatePriority(ctx, p))
+}
+
+func (s *TaxonomyService) UpdatePriority(ctx context.Context, p *models.TicketPriority) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	existing := findByID(t.Priorities, p.ID, func(x models.TicketPriority) string { return x.ID })
+	if existing == nil {
+		return ErrTaxonomyNotFound
+	}
+	if existing.Default && !p.Default {
+		return invalid("mark another priority as default instead")
+	}
+	if err := s.validatePriority(ctx, p); err != nil {
+		return err
+	}
+	return s.write(s.repo.UpdatePriority(ctx, p))
+}
+
+func (s *TaxonomyService) DeletePriority(ctx context.Context, id string) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	existing := findByID(t.Priorities, id, func(x models.TicketPriority) string { return x.ID })
+	if existing == nil {
+		return ErrTaxonomyNotFound
+	}
+	if existing.Default {
+		return invalid("the default priority cannot be deleted")
+	}
+	return s.write(s.repo.DeletePriority(ctx, id))
+}
+
+func (s *TaxonomyService) CreateCategory(ctx context.Context, c *models.TicketCategory) error {
+	c.ID = ""
+	if err := s.validateCategory(ctx, c); err != nil {
+		return err
+	}
+	return s.write(s.repo.CreateCategory(ctx, c))
+}
+
+func (s *TaxonomyService) UpdateCategory(ctx context.Context, c *models.TicketCategory) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if findByID(t.Categories, c.ID, func(x models.TicketCategory) string { return x.ID }) == nil {
+		return ErrTaxonomyNotFound
+	}
+	if err := s.validateCategory(ctx, c); err != nil {
+		return err
+	}
+	return s.write(s.repo.UpdateCategory(ctx, c))
+}
+
+func (s *TaxonomyService) DeleteCategory(ctx context.Context, id string) error {
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if findByID(t.Categories, id, func(x models.TicketCategory) string { return x.ID }) == nil {
+		return ErrTaxonomyNotFound
+	}
+	return s.write(s.repo.DeleteCategory(ctx, id))
+}
+
+// write invalidates the cache after a repository write and maps its errors.
+func (s *TaxonomyService) write(err error) error {
+	s.invalidate()
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrTaxonomyNotFound
+	case errors.Is(err, repository.ErrInUse):
+		return invalid("entry is still used by tickets or rules; deactivate it instead")
+	}
+	return err
+}
+
+func (s *TaxonomyService) validateStatus(ctx context.Context, st, existing *models.TicketStatus) error {
+	if s == nil || s.repo == nil {
+		return errors.New("taxonomy is not configurable")
+	}
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateTaxonomyEntry(&st.Name, &st.Color); err != nil {
+		return err
+	}
+	if existing != nil && existing.System {
+		if st.Name != existing.Name || st.Terminal != existing.Terminal {
+			return invalid("system statuses cannot be renamed or change their terminal flag")
+		}
+		if !st.Active {
+			return invalid("system statuses cannot be deactivated")
+		}
+	}
+	if other := findByName(t.Statuses, st.Name, func(x models.TicketStatus) string { return x.Name }); other != nil && other.ID != st.ID {
+		return invalid("status already exists")
+	}
+	seen := map[string]bool{}
+	next := make([]string, 0, len(st.Next))
+	for _, n := range st.Next {
+		n = strings.TrimSpace(n)
+		if n == "" || seen[n] {
+			continue
+		}
+		if n == st.Name || (existing != nil && n == existing.Name) {
+			return invalid("a status cannot transition to itself")
+		}
+		if t.Status(n) == nil {
+			return invalid("unknown next status: " + n)
+		}
+		seen[n] = true
+		next = append(next, n)
+	}
+	st.Next = next
+	return nil
+}
+
+func (s *TaxonomyService) validatePriority(ctx context.Context, p *models.TicketPriority) error {
+	if s == nil || s.repo == nil {
+		return errors.New("taxonomy is not configurable")
+	}
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateTaxonomyEntry(&p.Name, &p.Color); err != nil {
+		return err
+	}
+	if p.Default && !p.Active {
+		return invalid("the default priority must be active")
+	}
+	if other := findByName(t.Priorities, p.Name, func(x models.TicketPriority) string { return x.Name }); other != nil && other.ID != p.ID {
+		return invalid("priority already exists")
+	}
+	return nil
+}
+
+func (s *TaxonomyService) validateCategory(ctx context.Context, c *models.TicketCategory) error {
+	if s == nil || s.repo == nil {
+		return errors.New("taxonomy is not configurable")
+	}
+	t, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateTaxonomyEntry(&c.Name, &c.Color); err != nil {
+		return err
+	}
+	if other := findByName(t.Categories, c.Name, func(x models.TicketCategory) string { return x.Name }); other != nil && other.ID != c.ID {
+		return invalid("category already exists")
+	}
+	return nil
+}
+
+func validateTaxonomyEntry(name, color *string) error {
+	*name = strings.TrimSpace(*name)
+	*color = strings.TrimSpace(*color)
+	if *name == "" {
+		return invalid("name is required")
+	}
+	if utf8.RuneCountInString(*name) > 40 {
+		return invalid("name is too long")
+	}
+	if *color != "" && !hexColorRe.MatchString(*color) {
+		return invalid("color must look like #rrggbb")
+	}
+	return nil
+}
+
+func findByID[T any](items []T, id string, key func(T) string) *T {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil
+	}
+	for i := range items {
+		if key(items[i]) == id {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// findByName matches case-insensitively, like the unique name indexes.
+func findByName[T any](items []T, name string, key func(T) string) *T {
+	for i := range items {
+		if strings.EqualFold(key(items[i]), name) {
+			return &items[i]
+		}
+	}
+	return nil
+}
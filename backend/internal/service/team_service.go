@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var (
+	ErrTeamNotFound   = errors.New("team not found")
+	ErrMemberNotFound = errors.New("team member not found")
+)
+
+// rebalanceBatch caps how many tickets one rebalance moves.
+const rebalanceBatch = 200
+
+// TeamService manages teams and their ticket queues.
+type TeamService struct {
+	teams   repository.TeamRepository
+	users   repository.UserRepository
+	tickets repository.TicketRepository
+	svc     *TicketService
+}
+
+func NewTeamService(teams repository.TeamRepository, users repository.UserRepository, tickets repository.TicketRepository, svc *TicketService) *TeamService {
+	return &TeamService{teams: teams, users: users, tickets: tickets, svc: svc}
+}
+
+func (s *TeamService) List(ctx context.Context) ([]models.Team, error) {
+	return s.teams.List(ctx)
+}
+
+func (s *TeamService) Get(ctx context.Context, id string) (*models.Team, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTeamNotFound
+	}
+	t, err := s.teams.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTeamNotFound
+	}
+	return t, nil
+}
+
+func (s *TeamService) Create(ctx context.Context, t *models.Team) error {
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.teams.Create(ctx, t)
+}
+
+func (s *TeamService) Update(ctx context.Context, t *models.Team) error {
+	if _, err := s.Get(ctx, t.ID); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.teams.Update(ctx, t)
+}
+
+// Delete removes a team; its tickets drop out of the queue but keep their
+// assignee.
+func (s *TeamService) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.teams.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTeamNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SetMember adds a staff user to the team (or changes its lead flag).
+func (s *TeamService) SetMember(ctx context.Context, teamID, userID string, lead bool) (*models.Team, error) {
+	if _, err := s.Get(ctx, teamID); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	if _, ok := allowedAssigneeRoles[strings.ToLower(u.Role)]; !ok {
+		return nil, invalid("only staff users can join a team")
+	}
+	if err := s.teams.SetMember(ctx, teamID, userID, lead); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, teamID)
+}
+
+func (s *TeamService) RemoveMember(ctx context.Context, teamID, userID string) error {
+	if _, err := s.Get(ctx, teamID); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrMemberNotFound
+	}
+	err := s.teams.RemoveMember(ctx, teamID, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrMemberNotFound
+	}
+	return err
+}
+
+// CanViewQueue reports whether actor may see the team's queue: admins see
+// all queues, other staff only the queues of teams they belong to.
+func (s *TeamService) CanViewQueue(ctx context.Context, actor Actor, teamID string) (bool, error) {
+	if actor.Role == "admin" {
+		return true, nil
+	}
+	if !IsStaff(actor.Role) {
+		return false, nil
+	}
+	member, _, err := s.teams.Membership(ctx, teamID, actor.ID)
+	return member, err
+}
+
+// Queue lists tickets in a team queue. f.TeamID is forced to teamID.
+func (s *TeamService) Queue(ctx context.Context, actor Actor, teamID string, f repository.TicketFilter) ([]models.Ticket, int, error) {
+	if _, err := s.Get(ctx, teamID); err != nil {
+		return nil, 0, err
+	}
+	ok, err := s.CanViewQueue(ctx, actor, teamID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return nil, 0, ErrForbidden
+	}
+	f.TeamID = teamID
+	items, err := s.tickets.ListAdv(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.tickets.CountAdv(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// RebalanceMove records one reassignment made by Rebalance.
+type RebalanceMove struct {
+	TicketID string `json:"ticketId"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// Rebalance spreads the team's unassigned open tickets (or, when from is set,
+// that member's open tickets) over the team's other active staff members,
+// always picking the member with the fewest open tickets in the queue.
+// Only admins and supervisors who belong to the team may rebalance it.
+func (s *TeamService) Rebalance(ctx context.Context, actor Actor, teamID, from string) ([]RebalanceMove, error) {
+	team, err := s.Get(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != "admin" {
+		member, _, err := s.teams.Membership(ctx, teamID, actor.ID)
+		if err != nil {
+			return nil, err
+		}
+		if actor.Role != "supervisor" || !member {
+			return nil, ErrForbidden
+		}
+	}
+
+	var targets []string
+	for _, m := range team.Members {
+		if !m.Active || m.UserID == from {
+			continue
+		}
+		if _, ok := allowedAssigneeRoles[strings.ToLower(m.Role)]; ok {
+			targets = append(targets, m.UserID)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, invalid("team has no available members")
+	}
+
+	load, err := s.teams.OpenLoad(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	f := repository.TicketFilter{TeamID: teamID, OpenOnly: true, Limit: rebalanceBatch, Sort: "created_at", Order: "asc"}
+	if from != "" {
+		f.Assignee = from
+	} else {
+		f.Unassigned = true
+	}
+	tickets, err := s.tickets.ListAdv(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := []RebalanceMove{}
+	for _, t := range tickets {
+		to := targets[0]
+		for _, id := range targets[1:] {
+			if load[id] < load[to] {
+				to = id
+			}
+		}
+		if _, err := s.svc.Update(ctx, actor, t.ID, TicketPatch{Assignee: &to}); err != nil {
+			return moves, err
+		}
+		load[to]++
+		moves = append(moves, RebalanceMove{TicketID: t.ID, From: t.Assignee, To: to})
+	}
+	return moves, nil
+}
+
+func (s *TeamService) validate(ctx context.Context, t *models.Team) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	if t.Name == "" {
+		return invalid("name is required")
+	}
+	existing, err := s.teams.GetByName(ctx, t.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != t.ID {
+		return invalid("team name already exists")
+	}
+	return nil
+}
@@ -0,0 +1,688 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var (
+	ErrTicketNotFound    = errors.New("ticket not found")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrNoDefaultAssignee = errors.New("no active admin available for assignment")
+	ErrForbidden         = errors.New("forbidden")
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrAlreadyClaimed    = errors.New("ticket is already claimed")
+	// ErrVersionConflict means the ticket changed since the caller read it.
+	ErrVersionConflict = repository.ErrVersionConflict
+)
+
+// CommentEditWindow is how long authors may edit or delete their own
+// comments. Admins are not limited.
+const CommentEditWindow = 15 * time.Minute
+
+// ValidationError reports bad client input; handlers map it to 400.
+type ValidationError struct{ Msg string }
+
+func (e *ValidationError) Error() string { return e.Msg }
+
+func invalid(msg string) error { return &ValidationError{Msg: msg} }
+
+var (
+	allowedAssigneeRoles = map[string]struct{}{
+		"admin":      {},
+		"agent":      {},
+		"supervisor": {},
+	}
+	// staffRoles may read and write internal notes.
+	staffRoles = map[string]struct{}{
+		"admin":      {},
+		"agent":      {},
+		"supervisor": {},
+	}
+)
+
+// IsStaff reports whether role belongs to support staff (agent/supervisor/admin).
+func IsStaff(role string) bool {
+	_, ok := staffRoles[role]
+	return ok
+}
+
+// CanViewTicket reports whether actor may read t: end users only see the
+// tickets they created.
+func CanViewTicket(actor Actor, t *models.Ticket) bool {
+	if actor.Role == "end_user" && actor.ID != "" && t.CreatedBy != actor.ID {
+		return false
+	}
+	return true
+}
+
+// VisibleComments strips internal notes from comments unless role is staff.
+func VisibleComments(role string, comments []models.Comment) []models.Comment {
+	if IsStaff(role) {
+		return comments
+	}
+	out := make([]models.Comment, 0, len(comments))
+	for _, c := range comments {
+		if !c.Internal {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// StatusTransitions maps a status to the statuses it may move to.
+type StatusTransitions map[string][]string
+
+// DefaultStatusTransitions is the standard ticket lifecycle the status
+// taxonomy is seeded with:
+// New → Open → In Progress → Pending/Resolved → Closed, with Resolved and
+// Closed tickets reopenable back to Open.
+var DefaultStatusTransitions = StatusTransitions{
+	"New":         {"Open", "In Progress"},
+	"Open":        {"In Progress", "Pending", "Resolved"},
+	"In Progress": {"Open", "Pending", "Resolved"},
+	"Pending":     {"In Progress", "Resolved"},
+	"Resolved":    {"Closed", "Open"},
+	"Closed":      {"Open"},
+}
+
+// Allows reports whether a ticket may move from one status to another.
+// Staying in the same status is always allowed.
+func (st StatusTransitions) Allows(from, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range st[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor is the user on whose behalf a service call runs.
+type Actor struct {
+	ID   string
+	Role string
+}
+
+// TicketInput carries the fields accepted when creating a ticket.
+type TicketInput struct {
+	Title       string
+	Description string
+	Category    string
+	Priority    string
+	Department  string
+	Assignee    string
+	TeamID      string
+	// CustomFields holds values by field key (see applyCustomFields).
+	CustomFields map[string]any
+	// TemplateID names a ticket template whose defaults and required fields
+	// apply (see templateInput).
+	TemplateID string
+}
+
+// TicketPatch carries a partial ticket update; nil fields are left untouched.
+type TicketPatch struct {
+	Title       *string
+	Description *string
+	Category    *string
+	Priority    *string
+	Status      *string
+	Assignee    *string
+	Department  *string
+	TeamID      *string
+	// CustomFields sets the given keys; a nil value clears a field.
+	CustomFields map[string]any
+
+	// Version is the version the caller based the patch on (If-Match);
+	// 0 skips the check against the caller's copy.
+	Version int
+	// OverrideOpenChildren allows resolving/closing a parent ticket whose
+	// child tickets are still open.
+	OverrideOpenChildren bool
+}
+
+// changes reports whether p sets any field.
+func (p TicketPatch) changes() bool {
+	return p.Title != nil || p.Description != nil || p.Category != nil || p.Priority != nil ||
+		p.Status != nil || p.Assignee != nil || p.Department != nil || p.TeamID != nil ||
+		p.CustomFields != nil
+}
+
+// TicketService owns ticket business rules (validation, assignment and the
+// status lifecycle) so HTTP handlers and background jobs share them.
+type TicketService struct {
+	tickets     repository.TicketRepository
+	users       repository.UserRepository
+	teams       repository.TeamRepository
+	links       repository.TicketLinkRepository
+	fields      repository.CustomFieldRepository
+	templates   repository.TicketTemplateRepository
+	watchers    repository.WatcherRepository
+	notifier    *NotificationService
+	sla         *SLAService
+	assigner    *AssignmentService
+	taxonomy    *TaxonomyService
+	transitions StatusTransitions
+	now         func() time.Time
+}
+
+// TicketDeps wires a TicketService. Tickets and Users are required.
+type TicketDeps struct {
+	Tickets     repository.TicketRepository
+	Users       repository.UserRepository
+	Teams       repository.TeamRepository           // nil disables team queues
+	Links       repository.TicketLinkRepository     // nil disables ticket links
+	Fields      repository.CustomFieldRepository    // nil disables custom fields
+	Templates   repository.TicketTemplateRepository // nil disables ticket templates
+	Watchers    repository.WatcherRepository        // nil: no watchers (mentions and notifications skip them)
+	Notifier    *NotificationService                // nil disables notifications
+	SLA         *SLAService                         // nil disables SLA tracking
+	Assigner    *AssignmentService                  // nil: end-user tickets go to the first active admin
+	Taxonomy    *TaxonomyService                    // nil: built-in statuses/priorities/categories
+	Transitions StatusTransitions                   // nil: next statuses from the taxonomy
+}
+
+// NewTicketService builds the service from its dependencies.
+func NewTicketService(d TicketDeps) *TicketService {
+	if d.Assigner == nil {
+		d.Assigner = NewAssignmentService(nil, d.Users, d.Taxonomy)
+	}
+	return &TicketService{
+		tickets:     d.Tickets,
+		users:       d.Users,
+		teams:       d.Teams,
+		links:       d.Links,
+		fields:      d.Fields,
+		templates:   d.Templates,
+		watchers:    d.Watchers,
+		notifier:    d.Notifier,
+		sla:         d.SLA,
+		assigner:    d.Assigner,
+		taxonomy:    d.Taxonomy,
+		transitions: d.Transitions,
+		now:         time.Now,
+	}
+}
+
+// Create validates input, applies auto-assignment and stores a new ticket.
+// If the creator is an end_user, the assignment engine picks the assignee
+// (falling back to the first active admin); admins default to themselves.
+func (s *TicketService) Create(ctx context.Context, actor Actor, in TicketInput) (*models.Ticket, error) {
+	tpl, err := s.templateInput(ctx, &in)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(in.Title)
+	if title == "" && (tpl == nil || tpl.TitlePattern == "") {
+		return nil, invalid("title is required")
+	}
+
+	assignee := strings.TrimSpace(in.Assignee)
+	if actor.Role == "admin" && assignee == "" {
+		assignee = actor.ID
+	}
+
+	tax, err := s.taxonomy.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	priority := strings.TrimSpace(in.Priority)
+	if priority == "" {
+		priority = tax.DefaultPriority()
+	}
+	if err := s.taxonomy.ValidatePriority(ctx, priority); err != nil {
+		return nil, err
+	}
+
+	category := strings.TrimSpace(in.Category)
+	if err := s.taxonomy.ValidateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	t := &models.Ticket{
+		Title:       title,
+		Description: strings.TrimSpace(in.Description),
+		Category:    category,
+		Priority:    priority,
+		Status:      StatusNew,
+		Assignee:    assignee,
+		Department:  strings.TrimSpace(in.Department),
+		TeamID:      strings.TrimSpace(in.TeamID),
+		CreatedBy:   actor.ID,
+		CreatedAt:   s.now(),
+	}
+	if err := s.applyCustomFields(ctx, t, in.CustomFields, true); err != nil {
+		return nil, err
+	}
+	if tpl != nil {
+		if err := applyTemplate(t, tpl); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.resolveTeam(ctx, t); err != nil {
+		return nil, err
+	}
+	if actor.Role == "end_user" {
+		id, err := s.assigner.Assign(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		t.Assignee = id
+	}
+	if err := s.validateAssignee(ctx, t.Assignee); err != nil {
+		return nil, err
+	}
+	if s.sla != nil {
+		if err := s.sla.Apply(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.tickets.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	created, err := s.tickets.Get(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, errors.New("ticket not found after creation")
+	}
+	s.notifyChanges(ctx, actor.ID, &models.Ticket{Status: created.Status}, created)
+	return created, nil
+}
+
+// Update applies a partial change to a ticket, enforcing the status lifecycle.
+// Every changed field is recorded in the ticket history as done by actor.
+// It fails with ErrVersionConflict if the ticket changed after p.Version or
+// while this update was being prepared.
+func (s *TicketService) Update(ctx context.Context, actor Actor, id string, p TicketPatch) (*models.Ticket, error) {
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	if p.Version != 0 && p.Version != t.Version {
+		return nil, ErrVersionConflict
+	}
+	before := *t
+	events, err := s.applyPatch(ctx, actor, t, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tickets.Update(ctx, t, events); err != nil {
+		return nil, err
+	}
+
+	// Re-read so assignee name/email are populated via JOIN
+	updated, err := s.tickets.Get(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, errors.New("ticket not found after update")
+	}
+	s.notifyChanges(ctx, actor.ID, &before, updated)
+	return updated, nil
+}
+
+// applyPatch validates p and applies it to t (status via Transition, SLA
+// re-evaluation) without persisting. It returns the history events for the
+// change.
+func (s *TicketService) applyPatch(ctx context.Context, actor Actor, t *models.Ticket, p TicketPatch) ([]models.TicketEvent, error) {
+	before := *t
+
+	if p.Title != nil {
+		t.Title = strings.TrimSpace(*p.Title)
+	}
+	if p.Description != nil {
+		t.Description = strings.TrimSpace(*p.Description)
+	}
+	if p.Category != nil {
+		category := strings.TrimSpace(*p.Category)
+		if err := s.taxonomy.ValidateCategory(ctx, category); err != nil {
+			return nil, err
+		}
+		t.Category = category
+	}
+	if p.Priority != nil {
+		priority := strings.TrimSpace(*p.Priority)
+		if err := s.taxonomy.ValidatePriority(ctx, priority); err != nil {
+			return nil, err
+		}
+		t.Priority = priority
+	}
+	if p.Status != nil && t.MergedInto != "" && strings.TrimSpace(*p.Status) != t.Status {
+		return nil, fmt.Errorf("%w: ticket was merged into %s", ErrInvalidTransition, t.MergedInto)
+	}
+	if p.Status != nil {
+		to := strings.TrimSpace(*p.Status)
+		tax, err := s.taxonomy.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if tax.IsTerminal(to) && !tax.IsTerminal(t.Status) {
+			if err := s.checkOpenChildren(ctx, t, p.OverrideOpenChildren); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.Transition(ctx, t, to); err != nil {
+			return nil, err
+		}
+	}
+	if p.Assignee != nil {
+		assignee := strings.TrimSpace(*p.Assignee)
+		if err := s.validateAssignee(ctx, assignee); err != nil {
+			return nil, err
+		}
+		t.Assignee = assignee
+	}
+	if p.Department != nil {
+		t.Department = strings.TrimSpace(*p.Department)
+	}
+	if p.TeamID != nil {
+		t.TeamID = strings.TrimSpace(*p.TeamID)
+		if err := s.validateTeam(ctx, t.TeamID); err != nil {
+			return nil, err
+		}
+	}
+	// Required fields are only enforced when fields or the category change,
+	// so older tickets stay editable after a field becomes required.
+	if p.CustomFields != nil || t.Category != before.Category {
+		if err := s.applyCustomFields(ctx, t, p.CustomFields, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// Priority/category/department select the SLA policy
+	if s.sla != nil && (t.Priority != before.Priority || t.Category != before.Category || t.Department != before.Department) {
+		if err := s.sla.Apply(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	events := diffTicket(&before, t, actor.ID)
+	return append(events, diffCustomFields(before.CustomFields, t.CustomFields, t.ID, actor.ID)...), nil
+}
+
+// Transition moves t to status `to` if the transition table allows it and
+// applies the lifecycle side effects (resolved/closed stamps, reopen reset,
+// SLA pause while Pending, first response when leaving New). Terminal
+// statuses other than Closed count as resolved.
+// It only mutates t; persisting is up to the caller.
+func (s *TicketService) Transition(ctx context.Context, t *models.Ticket, to string) error {
+	if err := s.taxonomy.ValidateStatus(ctx, to); err != nil {
+		return err
+	}
+	from := t.Status
+	if from == to {
+		return nil
+	}
+	transitions := s.transitions
+	if transitions == nil {
+		var err error
+		if transitions, err = s.taxonomy.Transitions(ctx); err != nil {
+			return err
+		}
+	}
+	if !transitions.Allows(from, to) {
+		return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, from, to)
+	}
+	terminal, err := s.taxonomy.IsTerminal(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	if from == StatusNew && t.SLA.FirstRespondedAt == nil {
+		t.SLA.FirstRespondedAt = &now
+	}
+	if s.sla != nil {
+		if to == StatusPending {
+			s.sla.Pause(t, now)
+		} else if from == StatusPending {
+			if err := s.sla.Resume(ctx, t, now); err != nil {
+				return err
+			}
+		}
+	}
+	switch {
+	case to == StatusClosed:
+		if t.ResolvedAt == nil {
+			t.ResolvedAt = &now
+		}
+		t.ClosedAt = &now
+	case terminal:
+		t.ResolvedAt = &now
+		t.ClosedAt = nil
+	default:
+		// Any move back into an active status is a reopen.
+		t.ResolvedAt = nil
+		t.ClosedAt = nil
+	}
+	t.Status = to
+	return nil
+}
+
+// AddComment appends a comment authored by actor. Only staff may post
+// internal notes. @-mentions in text are resolved and stored with it.
+func (s *TicketService) AddComment(ctx context.Context, actor Actor, ticketID, text string, internal bool) (*models.Comment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, invalid("text is required")
+	}
+	if internal && !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.tickets.AddComment(ctx, t.ID, actor.ID, text, internal)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.syncMentions(ctx, actor, t, c, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyMentions(ctx, actor.ID, t, c, mentions)
+	s.notifyComment(ctx, actor.ID, t, c, mentions)
+	// The first public staff reply satisfies the first-response SLA
+	if IsStaff(actor.Role) && !internal {
+		if err := s.tickets.MarkFirstResponse(ctx, ticketID, c.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// UpdateComment replaces a comment's text, keeping the old text as a revision.
+func (s *TicketService) UpdateComment(ctx context.Context, actor Actor, ticketID, commentID, text string) (*models.Comment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, invalid("text is required")
+	}
+	c, err := s.editableComment(ctx, actor, ticketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Text == text {
+		return c, nil
+	}
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.tickets.UpdateComment(ctx, c.ID, actor.ID, text)
+	if err != nil {
+		return nil, err
+	}
+	added, err := s.syncMentions(ctx, actor, t, updated, c.Mentions)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyMentions(ctx, actor.ID, t, updated, added)
+	return updated, nil
+}
+
+// DeleteComment soft-deletes a comment; the same rules as editing apply.
+func (s *TicketService) DeleteComment(ctx context.Context, actor Actor, ticketID, commentID string) error {
+	c, err := s.editableComment(ctx, actor, ticketID, commentID)
+	if err != nil {
+		return err
+	}
+	return s.tickets.DeleteComment(ctx, c.ID, actor.ID)
+}
+
+// CommentRevisions returns prior versions of a comment (staff only).
+func (s *TicketService) CommentRevisions(ctx context.Context, actor Actor, ticketID, commentID string) ([]models.CommentRevision, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	c, err := s.tickets.GetComment(ctx, ticketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCommentNotFound
+	}
+	return s.tickets.CommentRevisions(ctx, c.ID)
+}
+
+// editableComment loads a comment and checks actor may change it: admins
+// always, authors within CommentEditWindow.
+func (s *TicketService) editableComment(ctx context.Context, actor Actor, ticketID, commentID string) (*models.Comment, error) {
+	c, err := s.tickets.GetComment(ctx, ticketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || (c.Internal && !IsStaff(actor.Role)) {
+		return nil, ErrCommentNotFound
+	}
+	if actor.Role == "admin" {
+		return c, nil
+	}
+	if c.AuthorID == "" || c.AuthorID != actor.ID {
+		return nil, ErrForbidden
+	}
+	if s.now().Sub(c.CreatedAt) > CommentEditWindow {
+		return nil, fmt.Errorf("%w: edit window has expired", ErrForbidden)
+	}
+	return c, nil
+}
+
+// History returns the change events of a ticket, oldest first.
+func (s *TicketService) History(ctx context.Context, id string) ([]models.TicketEvent, error) {
+	return s.tickets.History(ctx, id)
+}
+
+// diffTicket returns one change event per audited field that differs
+// between before and after.
+func diffTicket(before, after *models.Ticket, actorID string) []models.TicketEvent {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"title", before.Title, after.Title},
+		{"description", before.Description, after.Description},
+		{"category", before.Category, after.Category},
+		{"priority", before.Priority, after.Priority},
+		{"status", before.Status, after.Status},
+		{"assignee", before.Assignee, after.Assignee},
+		{"department", before.Department, after.Department},
+		{"team", before.TeamID, after.TeamID},
+	}
+	var events []models.TicketEvent
+	for _, f := range fields {
+		if f.old == f.new {
+			continue
+		}
+		events = append(events, models.TicketEvent{
+			TicketID: after.ID,
+			ActorID:  actorID,
+			Type:     models.TicketEventChange,
+			Field:    f.name,
+			OldValue: f.old,
+			NewValue: f.new,
+		})
+	}
+	return events
+}
+
+func (s *TicketService) validateAssignee(ctx context.Context, assignee string) error {
+	if strings.TrimSpace(assignee) == "" {
+		return nil
+	}
+
+	if _, err := uuid.Parse(strings.TrimSpace(assignee)); err != nil {
+		return invalid("invalid assignee id")
+	}
+
+	if s.users == nil {
+		return nil
+	}
+
+	u, err := s.users.GetByID(ctx, assignee)
+	if err != nil {
+		return err
+	}
+	if u == nil || !u.Active {
+		return invalid("assignee not found or inactive")
+	}
+	if _, ok := allowedAssigneeRoles[strings.ToLower(u.Role)]; !ok {
+		return invalid("assignee role not permitted")
+	}
+	return nil
+}
+
+// resolveTeam validates t.TeamID, or queues the ticket to the team named like
+// its department when no team was given (departments predate teams).
+func (s *TicketService) resolveTeam(ctx context.Context, t *models.Ticket) error {
+	if t.TeamID != "" {
+		return s.validateTeam(ctx, t.TeamID)
+	}
+	if s.teams == nil || t.Department == "" {
+		return nil
+	}
+	team, err := s.teams.GetByName(ctx, t.Department)
+	if err != nil {
+		return err
+	}
+	if team != nil {
+		t.TeamID = team.ID
+	}
+	return nil
+}
+
+func (s *TicketService) validateTeam(ctx context.Context, teamID string) error {
+	if teamID == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(teamID); err != nil {
+		return invalid("invalid team id")
+	}
+	if s.teams == nil {
+		return nil
+	}
+	team, err := s.teams.Get(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return invalid("team not found")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+func TestUpdateVersion(t *testing.T) {
+	title := "Printer on fire"
+	agent := Actor{ID: "a1", Role: "agent"}
+	tests := []struct {
+		name    string
+		version int
+		want    error
+	}{
+		{"no precondition", 0, nil},
+		{"current version", 3, nil},
+		{"stale version", 2, ErrVersionConflict},
+		{"future version", 4, ErrVersionConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := newFakeTickets(models.Ticket{ID: "t1", Title: "Printer jammed", Status: "Open", Version: 3})
+			s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+			got, err := s.Update(context.Background(), agent, "t1", TicketPatch{Title: &title, Version: tt.version})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Update = %v, want %v", err, tt.want)
+			}
+			stored, _ := tickets.Get(context.Background(), "t1")
+			if err != nil {
+				if stored.Title != "Printer jammed" || stored.Version != 3 {
+					t.Fatalf("rejected update was stored: %+v", stored)
+				}
+				return
+			}
+			if got.Title != title || got.Version != 4 || stored.Version != 4 {
+				t.Fatalf("got %q v%d (stored v%d), want %q v4", got.Title, got.Version, stored.Version, title)
+			}
+		})
+	}
+}
+
+// Writers that read the same version race to save; the version check in the
+// repository must let exactly one of them win.
+func TestUpdateVersionRace(t *testing.T) {
+	const writers = 10
+	tickets := newFakeTickets(models.Ticket{ID: "t1", Title: "Printer jammed", Status: "Open"})
+	s := NewTicketService(TicketDeps{Tickets: tickets, Users: newFakeUsers()})
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		won, lost int
+	)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			title := "edited"
+			_, err := s.Update(context.Background(), Actor{ID: "a1", Role: "agent"}, "t1", TicketPatch{Title: &title, Version: 1})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				won++
+			case errors.Is(err, ErrVersionConflict):
+				lost++
+			default:
+				t.Errorf("Update: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if won != 1 || lost != writers-1 {
+		t.Fatalf("%d updates won and %d conflicted, want 1 and %d", won, lost, writers-1)
+	}
+	if got, _ := tickets.Get(context.Background(), "t1"); got.Version != 2 {
+		t.Fatalf("version = %d, want 2", got.Version)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrTicketTemplateNotFound = errors.New("ticket template not found")
+
+// templatePlaceholderRe matches {{key}} placeholders in title patterns.
+var templatePlaceholderRe = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// TicketTemplateService manages per-category request templates. Templates
+// are applied by TicketService.Create when a ticket names one.
+type TicketTemplateService struct {
+	templates repository.TicketTemplateRepository
+	fields    repository.CustomFieldRepository
+	taxonomy  *TaxonomyService
+}
+
+func NewTicketTemplateService(templates repository.TicketTemplateRepository, fields repository.CustomFieldRepository, taxonomy *TaxonomyService) *TicketTemplateService {
+	return &TicketTemplateService{templates: templates, fields: fields, taxonomy: taxonomy}
+}
+
+func (s *TicketTemplateService) List(ctx context.Context, category string, activeOnly bool) ([]models.TicketTemplate, error) {
+	return s.templates.List(ctx, strings.TrimSpace(category), activeOnly)
+}
+
+func (s *TicketTemplateService) Get(ctx context.Context, id string) (*models.TicketTemplate, error) {
+	return s.get(ctx, id)
+}
+
+func (s *TicketTemplateService) Create(ctx context.Context, t *models.TicketTemplate) error {
+	t.ID = ""
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.templates.Create(ctx, t)
+}
+
+func (s *TicketTemplateService) Update(ctx context.Context, t *models.TicketTemplate) error {
+	if _, err := s.get(ctx, t.ID); err != nil {
+		return err
+	}
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	return s.templates.Update(ctx, t)
+}
+
+func (s *TicketTemplateService) Delete(ctx context.Context, id string) error {
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	err := s.templates.Delete(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTicketTemplateNotFound
+	}
+	return err
+}
+
+// validate normalizes t and checks that its category and priority exist and
+// that required fields and title placeholders name custom fields usable in
+// the template's category.
+func (s *TicketTemplateService) validate(ctx context.Context, t *models.TicketTemplate) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Category = strings.TrimSpace(t.Category)
+	t.Priority = strings.TrimSpace(t.Priority)
+	t.TitlePattern = strings.TrimSpace(t.TitlePattern)
+	t.Description = strings.TrimSpace(t.Description)
+	t.RequiredFields = dedupeTrimmed(t.RequiredFields)
+
+	if t.Name == "" {
+		return invalid("name is required")
+	}
+	if utf8.RuneCountInString(t.Name) > 80 {
+		return invalid("name is too long")
+	}
+	if utf8.RuneCountInString(t.TitlePattern) > 200 {
+		return invalid("title pattern is too long")
+	}
+	if err := s.taxonomy.ValidateCategory(ctx, t.Category); err != nil {
+		return err
+	}
+	if t.Priority != "" {
+		if err := s.taxonomy.ValidatePriority(ctx, t.Priority); err != nil {
+			return err
+		}
+	}
+
+	keys := slices.Clone(t.RequiredFields)
+	for _, m := range templatePlaceholderRe.FindAllStringSubmatch(t.TitlePattern, -1) {
+		keys = append(keys, m[1])
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if s.fields == nil {
+		return invalid("custom fields are not enabled")
+	}
+	defs, err := s.fields.List(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		i := slices.IndexFunc(defs, func(f models.CustomField) bool { return f.Key == key })
+		if i < 0 {
+			return invalid("unknown custom field: " + key)
+		}
+		if !defs[i].AppliesTo(t.Category) {
+			return invalid("custom field " + key + " does not apply to category " + t.Category)
+		}
+	}
+	return nil
+}
+
+func (s *TicketTemplateService) get(ctx context.Context, id string) (*models.TicketTemplate, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTicketTemplateNotFound
+	}
+	t, err := s.templates.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketTemplateNotFound
+	}
+	return t, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+)
+
+// templateInput loads the template named by in.TemplateID (nil if none) and
+// fills in's category, default priority and description from it. A category
+// other than the template's is rejected.
+func (s *TicketService) templateInput(ctx context.Context, in *TicketInput) (*models.TicketTemplate, error) {
+	id := strings.TrimSpace(in.TemplateID)
+	if id == "" {
+		return nil, nil
+	}
+	if s.templates == nil {
+		return nil, invalid("ticket templates are not enabled")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, invalid("unknown template")
+	}
+	tpl, err := s.templates.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tpl == nil {
+		return nil, invalid("unknown template")
+	}
+	if !tpl.Active {
+		return nil, invalid("template is inactive")
+	}
+
+	if c := strings.TrimSpace(in.Category); c != "" && c != tpl.Category {
+		return nil, invalid("category must be " + tpl.Category + " for this template")
+	}
+	in.Category = tpl.Category
+	if strings.TrimSpace(in.Priority) == "" {
+		in.Priority = tpl.Priority
+	}
+	if strings.TrimSpace(in.Description) == "" {
+		in.Description = tpl.Description
+	}
+	return tpl, nil
+}
+
+// applyTemplate checks tpl's required custom fields on t and, when t has no
+// title, renders it from the template's title pattern.
+func applyTemplate(t *models.Ticket, tpl *models.TicketTemplate) error {
+	for _, key := range tpl.RequiredFields {
+		if _, ok := t.CustomFields[key]; !ok {
+			return invalid(key + " is required")
+		}
+	}
+	if t.Title == "" {
+		t.Title = renderTitle(tpl.TitlePattern, t.CustomFields)
+	}
+	if t.Title == "" {
+		return invalid("title is required")
+	}
+	return nil
+}
+
+// renderTitle replaces {{key}} placeholders with custom field values;
+// missing values render as empty.
+func renderTitle(pattern string, values map[string]any) string {
+	title := templatePlaceholderRe.ReplaceAllStringFunc(pattern, func(m string) string {
+		key := templatePlaceholderRe.FindStringSubmatch(m)[1]
+		switch v := values[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		case []any:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					parts = append(parts, s)
+				}
+			}
+			return strings.Join(parts, ", ")
+		}
+		return ""
+	})
+	return strings.Join(strings.Fields(title), " ")
+}
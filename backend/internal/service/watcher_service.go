@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+)
+
+var ErrWatcherNotFound = errors.New("watcher not found")
+
+// WatcherService manages who follows a ticket. Anyone who can see a ticket
+// may follow or unfollow it; staff add and remove other users (CCs). The
+// creator and assignee are added automatically by the database.
+type WatcherService struct {
+	watchers repository.WatcherRepository
+	tickets  repository.TicketRepository
+	users    repository.UserRepository
+}
+
+func NewWatcherService(watchers repository.WatcherRepository, tickets repository.TicketRepository, users repository.UserRepository) *WatcherService {
+	return &WatcherService{watchers: watchers, tickets: tickets, users: users}
+}
+
+// List returns the watchers of a ticket actor can see.
+func (s *WatcherService) List(ctx context.Context, actor Actor, ticketID string) ([]models.Watcher, error) {
+	t, err := s.ticket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.watchers.List(ctx, t.ID)
+}
+
+// Follow makes actor watch the ticket and returns its watchers.
+func (s *WatcherService) Follow(ctx context.Context, actor Actor, ticketID string) ([]models.Watcher, error) {
+	t, err := s.ticket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.watchers.Add(ctx, t.ID, actor.ID, models.WatchFollow, actor.ID); err != nil {
+		return nil, err
+	}
+	return s.watchers.List(ctx, t.ID)
+}
+
+// Unfollow stops actor watching the ticket. It is a no-op if actor was not
+// watching.
+func (s *WatcherService) Unfollow(ctx context.Context, actor Actor, ticketID string) error {
+	t, err := s.ticket(ctx, actor, ticketID)
+	if err != nil {
+		return err
+	}
+	_, err = s.watchers.Remove(ctx, t.ID, actor.ID)
+	return err
+}
+
+// AddCC lets staff add another user who can view the ticket as a watcher
+// and returns the watchers.
+func (s *WatcherService) AddCC(ctx context.Context, actor Actor, ticketID, userID string) ([]models.Watcher, error) {
+	if !IsStaff(actor.Role) {
+		return nil, ErrForbidden
+	}
+	t, err := s.ticket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	userID = strings.TrimSpace(userID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, invalid("userId is required")
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || !u.Active {
+		return nil, invalid("user not found or inactive")
+	}
+	if !CanViewTicket(Actor{ID: u.ID, Role: u.Role}, t) {
+		return nil, invalid("user cannot view this ticket")
+	}
+	if _, err := s.watchers.Add(ctx, t.ID, u.ID, models.WatchCC, actor.ID); err != nil {
+		return nil, err
+	}
+	return s.watchers.List(ctx, t.ID)
+}
+
+// Remove takes userID off the watcher list. Staff may remove anyone; other
+// users only themselves.
+func (s *WatcherService) Remove(ctx context.Context, actor Actor, ticketID, userID string) error {
+	if !IsStaff(actor.Role) && userID != actor.ID {
+		return ErrForbidden
+	}
+	t, err := s.ticket(ctx, actor, ticketID)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrWatcherNotFound
+	}
+	ok, err := s.watchers.Remove(ctx, t.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWatcherNotFound
+	}
+	return nil
+}
+
+// ticket loads a ticket actor may view.
+func (s *WatcherService) ticket(ctx context.Context, actor Actor, id string) (*models.Ticket, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	t, err := s.tickets.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTicketNotFound
+	}
+	if !CanViewTicket(actor, t) {
+		return nil, ErrForbidden
+	}
+	return t, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"gh-ts/internal/models"
+)
+
+const (
+	watchedTicketID = "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6c01"
+	agentID         = "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6ca1"
+	requesterID     = "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6cb1"
+	strangerID      = "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6cb2"
+	inactiveID      = "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6cb3"
+)
+
+func newTestWatcherService() (*WatcherService, *fakeWatchers) {
+	watchers := &fakeWatchers{}
+	return NewWatcherService(
+		watchers,
+		newFakeTickets(models.Ticket{ID: watchedTicketID, Status: "Open", CreatedBy: requesterID}),
+		newFakeUsers(
+			models.User{ID: agentID, Role: "agent", Active: true},
+			models.User{ID: requesterID, Role: "end_user", Active: true},
+			models.User{ID: strangerID, Role: "end_user", Active: true},
+			models.User{ID: inactiveID, Role: "agent"},
+		),
+	), watchers
+}
+
+func TestAddCC(t *testing.T) {
+	staff := Actor{ID: "s1", Role: "supervisor"}
+	tests := []struct {
+		name   string
+		actor  Actor
+		ticket string
+		user   string
+		want   error
+		msg    string
+	}{
+		{"agent", staff, watchedTicketID, agentID, nil, ""},
+		{"the requester", staff, watchedTicketID, requesterID, nil, ""},
+		{"another end user", staff, watchedTicketID, strangerID, nil, "user cannot view this ticket"},
+		{"inactive user", staff, watchedTicketID, inactiveID, nil, "user not found or inactive"},
+		{"unknown user", staff, watchedTicketID, "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6cff", nil, "user not found or inactive"},
+		{"malformed user id", staff, watchedTicketID, "agent", nil, "userId is required"},
+		{"unknown ticket", staff, "5e2d7c91-0a4b-4f6e-8d3c-9b1a2e7f6c0f", agentID, ErrTicketNotFound, ""},
+		{"by an end user", Actor{ID: requesterID, Role: "end_user"}, watchedTicketID, agentID, ErrForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, watchers := newTestWatcherService()
+			_, err := s.AddCC(context.Background(), tt.actor, tt.ticket, tt.user)
+			if tt.msg != "" {
+				wantErr(t, err, tt.msg)
+			} else if !errors.Is(err, tt.want) {
+				t.Fatalf("AddCC = %v, want %v", err, tt.want)
+			}
+			if added := watchers.watching(tt.ticket, tt.user); added != (err == nil) {
+				t.Fatalf("watching = %v after AddCC error %v", added, err)
+			}
+		})
+	}
+}
+
+func TestFollowAndRemove(t *testing.T) {
+	ctx := context.Background()
+	s, watchers := newTestWatcherService()
+	requester := Actor{ID: requesterID, Role: "end_user"}
+	stranger := Actor{ID: strangerID, Role: "end_user"}
+
+	if _, err := s.Follow(ctx, stranger, watchedTicketID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("stranger Follow = %v, want ErrForbidden", err)
+	}
+	if _, err := s.Follow(ctx, requester, watchedTicketID); err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	if _, err := s.AddCC(ctx, Actor{ID: "s1", Role: "agent"}, watchedTicketID, agentID); err != nil {
+		t.Fatalf("AddCC: %v", err)
+	}
+	if err := s.Remove(ctx, requester, watchedTicketID, agentID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("end user removing another watcher = %v, want ErrForbidden", err)
+	}
+	if err := s.Remove(ctx, requester, watchedTicketID, requesterID); err != nil {
+		t.Fatalf("Remove self: %v", err)
+	}
+	if err := s.Remove(ctx, Actor{ID: "s1", Role: "agent"}, watchedTicketID, requesterID); !errors.Is(err, ErrWatcherNotFound) {
+		t.Fatalf("Remove of a non-watcher = %v, want ErrWatcherNotFound", err)
+	}
+	if err := s.Unfollow(ctx, requester, watchedTicketID); err != nil {
+		t.Fatalf("Unfollow of a non-watcher: %v", err)
+	}
+	if !watchers.watching(watchedTicketID, agentID) || watchers.watching(watchedTicketID, requesterID) {
+		t.Fatalf("watchers = %+v", watchers.users[watchedTicketID])
+	}
+}
+
+// Watchers who may no longer read a ticket (e.g. an end user CC'd on a
+// ticket that was merged elsewhere) are left out of its notifications.
+func TestWatcherFanOut(t *testing.T) {
+	notes := &fakeNotifications{}
+	tickets := newFakeTickets(models.Ticket{ID: "t1", Status: "Open", CreatedBy: "u1"})
+	s := NewTicketService(TicketDeps{
+		Tickets: tickets,
+		Users:   newFakeUsers(models.User{ID: agentID, Name: "Alex", Role: "agent", Active: true}),
+		Watchers: &fakeWatchers{users: map[string][]models.User{"t1": {
+			{ID: "a1", Role: "agent"},
+			{ID: "s1", Role: "supervisor"},
+			{ID: "u1", Role: "end_user"},
+			{ID: "u2", Role: "end_user"},
+		}}},
+		Notifier: newNotifier(notes),
+	})
+	resolved, assignee := StatusResolved, agentID
+	if _, err := s.Update(context.Background(), Actor{ID: "a1", Role: "agent"}, "t1", TicketPatch{Status: &resolved, Assignee: &assignee}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	want := []string{"Assigned to Alex", "Status changed from Open to Resolved"}
+	for _, id := range []string{"s1", "u1"} {
+		if got := notes.messages(id); !slices.Equal(got, want) {
+			t.Errorf("%s got %q, want %q", id, got, want)
+		}
+	}
+	if got := notes.messages(agentID); !slices.Equal(got, []string{"Assigned to you"}) {
+		t.Errorf("assignee got %q", got)
+	}
+	for _, id := range []string{"a1", "u2"} {
+		if got := notes.messages(id); len(got) != 0 {
+			t.Errorf("%s got %q, want nothing", id, got)
+		}
+	}
+}
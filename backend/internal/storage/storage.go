@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by Open/Delete when the key does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Storage is a minimal blob store for attachment contents. Keys are
+// slash-separated relative paths (e.g. "<ticketID>/<attachmentID>").
+// LocalStorage is the default; an S3-compatible backend (MinIO in dev) can
+// be plugged in by implementing the same interface.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// Walk calls fn for every stored object (used for orphan cleanup).
+	Walk(ctx context.Context, fn func(key string, modTime time.Time) error) error
+}
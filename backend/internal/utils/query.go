@@ -3,6 +3,7 @@ package utils
 import (
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // QueryInt safely parses an integer from query parameters.
@@ -18,3 +19,17 @@ func QueryInt(q url.Values, key string, def int) int {
 	}
 	return n
 }
+
+// QueryList returns the non-empty values of a query parameter given either
+// repeated (?tag=a&tag=b) or comma-separated (?tag=a,b).
+func QueryList(q url.Values, key string) []string {
+	var out []string
+	for _, v := range q[key] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
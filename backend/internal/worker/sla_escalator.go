@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"gh-ts/internal/models"
+	"gh-ts/internal/repository"
+	"gh-ts/internal/service"
+)
+
+// SLAEscalator periodically finds tickets nearing or past their SLA deadlines
+// and escalates them through service.TicketService.Escalate. At-risk tickets
+// only get a note; breached tickets get every configured action.
+type SLAEscalator struct {
+	pool    *pgxpool.Pool
+	tickets repository.TicketRepository
+	svc     *service.TicketService
+	actions []service.EscalationAction
+	log     zerolog.Logger
+	batch   int
+}
+
+func NewSLAEscalator(pool *pgxpool.Pool, tickets repository.TicketRepository, svc *service.TicketService, actions []service.EscalationAction, log zerolog.Logger) *SLAEscalator {
+	return &SLAEscalator{pool: pool, tickets: tickets, svc: svc, actions: actions, log: log, batch: 100}
+}
+
+// Run blocks until ctx is cancelled, escalating every interval.
+func (e *SLAEscalator) Run(ctx context.Context, interval time.Duration) {
+	e.log.Info().Dur("interval", interval).Msg("sla escalator started")
+	Every(ctx, interval, func(ctx context.Context) {
+		if _, err := WithAdvisoryLock(ctx, e.pool, LockSLAEscalation, e.escalate); err != nil && ctx.Err() == nil {
+			e.log.Error().Err(err).Msg("sla escalation round failed")
+		}
+	})
+	e.log.Info().Msg("sla escalator stopped")
+}
+
+func (e *SLAEscalator) escalate(ctx context.Context) error {
+	items, err := e.tickets.ListSLAEscalations(ctx, e.batch)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		if ctx.Err() != nil {
+			return nil
+		}
+		t := &items[i]
+		level, actions := models.SLALevelAtRisk, e.atRiskActions()
+		if t.SLA.FirstResponseBreached || t.SLA.ResolutionBreached {
+			level, actions = models.SLALevelBreached, e.actions
+		}
+		if err := e.svc.Escalate(ctx, t, level, actions); err != nil {
+			if errors.Is(err, service.ErrVersionConflict) {
+				// Edited meanwhile; it is re-evaluated on the next run
+				e.log.Debug().Str("ticket", t.ID).Msg("sla escalation skipped: ticket changed")
+				continue
+			}
+			e.log.Error().Err(err).Str("ticket", t.ID).Msg("sla escalation failed")
+			continue
+		}
+		e.log.Info().Str("ticket", t.ID).Int("level", level).Msg("sla escalated")
+	}
+	return nil
+}
+
+// atRiskActions keeps only the note action: at-risk tickets are flagged, not reshuffled.
+func (e *SLAEscalator) atRiskActions() []service.EscalationAction {
+	for _, a := range e.actions {
+		if a == service.EscalateNote {
+			return []service.EscalationAction{a}
+		}
+	}
+	return nil
+}
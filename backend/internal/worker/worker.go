@@ -0,0 +1,60 @@
+// Package worker hosts background jobs started from cmd/api. Jobs stop when
+// their context is cancelled so they take part in graceful shutdown.
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Advisory lock keys (pg_try_advisory_lock) for jobs that must run on one
+// replica at a time. Keep them unique across the app.
+const (
+	LockSLAEscalation int64 = 740001
+)
+
+// Every calls fn right away and then every interval until ctx is done.
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithAdvisoryLock runs fn only if this process obtains the Postgres
+// advisory lock key; otherwise another replica is the leader for this round
+// and it returns (false, nil). The lock is session-scoped, so it is taken and
+// released on one dedicated pooled connection.
+func WithAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, key int64, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		// Unlock with a fresh context so shutdown cancellation can't leak the lock.
+		uctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(uctx, `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			// Drop the connection; closing the session releases the lock.
+			_ = conn.Conn().Close(uctx)
+		}
+	}()
+	return true, fn(ctx)
+}